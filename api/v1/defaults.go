@@ -0,0 +1,33 @@
+package v1
+
+import (
+	"sync/atomic"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// OperatorDefaults are the defaults of the operator configuration for the fields that are not set in the spec of an application
+// +kubebuilder:object:generate=false
+type OperatorDefaults struct {
+	PullPolicy     corev1.PullPolicy
+	Resources      *corev1.ResourceRequirements
+	LivenessProbe  *corev1.Probe
+	ReadinessProbe *corev1.Probe
+	StartupProbe   *corev1.Probe
+}
+
+var operatorDefaults atomic.Value
+
+func init() {
+	SetOperatorDefaults(&OperatorDefaults{PullPolicy: corev1.PullIfNotPresent})
+}
+
+// SetOperatorDefaults replaces the defaults of the operator configuration. The defaults must not be modified once they are set.
+func SetOperatorDefaults(defaults *OperatorDefaults) {
+	operatorDefaults.Store(defaults)
+}
+
+// GetOperatorDefaults returns the defaults of the operator configuration
+func GetOperatorDefaults() *OperatorDefaults {
+	return operatorDefaults.Load().(*OperatorDefaults)
+}
@@ -53,6 +53,8 @@ const (
 	OperationStatusConditionTypeStarted OperationStatusConditionType = "Started"
 	// OperationStatusConditionTypeCompleted indicates whether operation has been completed
 	OperationStatusConditionTypeCompleted OperationStatusConditionType = "Completed"
+	// OperationStatusConditionTypePaused indicates whether the reconciliation of the operation is paused
+	OperationStatusConditionTypePaused OperationStatusConditionType = "Paused"
 )
 
 // GetOperationCondtion returns condition of specific type
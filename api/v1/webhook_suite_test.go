@@ -0,0 +1,239 @@
+package v1
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+)
+
+var (
+	k8sClient client.Client
+	testEnv   *envtest.Environment
+	namespace = "default"
+)
+
+// TestMain starts an API server with the webhooks of the manager registered, so the tests go through admission
+func TestMain(m *testing.M) {
+	if os.Getenv("KUBEBUILDER_ASSETS") == "" {
+		fmt.Println("Skipping the webhook tests, because KUBEBUILDER_ASSETS is not set. Run make test to set up envtest.")
+		os.Exit(0)
+	}
+	logf.SetLogger(zap.New(zap.UseDevMode(true)))
+
+	testEnv = &envtest.Environment{
+		CRDDirectoryPaths:     []string{filepath.Join("..", "..", "config", "crd", "bases")},
+		ErrorIfCRDPathMissing: true,
+		WebhookInstallOptions: envtest.WebhookInstallOptions{
+			Paths: []string{filepath.Join("..", "..", "config", "webhook")},
+		},
+	}
+	cfg, err := testEnv.Start()
+	if err != nil {
+		fmt.Println("Failed to start envtest:", err)
+		os.Exit(1)
+	}
+
+	code, err := runWithManager(cfg, m)
+	if err != nil {
+		fmt.Println(err)
+		code = 1
+	}
+	if err := testEnv.Stop(); err != nil {
+		fmt.Println("Failed to stop envtest:", err)
+	}
+	os.Exit(code)
+}
+
+func runWithManager(cfg *rest.Config, m *testing.M) (int, error) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		return 1, err
+	}
+	if err := AddToScheme(scheme); err != nil {
+		return 1, err
+	}
+
+	webhookInstallOptions := &testEnv.WebhookInstallOptions
+	mgr, err := ctrl.NewManager(cfg, ctrl.Options{
+		Scheme:             scheme,
+		Host:               webhookInstallOptions.LocalServingHost,
+		Port:               webhookInstallOptions.LocalServingPort,
+		CertDir:            webhookInstallOptions.LocalServingCertDir,
+		LeaderElection:     false,
+		MetricsBindAddress: "0",
+	})
+	if err != nil {
+		return 1, fmt.Errorf("Failed to create manager: %v", err)
+	}
+	if err := (&WebSphereLibertyApplication{}).SetupWebhookWithManager(mgr); err != nil {
+		return 1, err
+	}
+	if err := (&WebSphereLibertyDump{}).SetupWebhookWithManager(mgr); err != nil {
+		return 1, err
+	}
+	if err := (&WebSphereLibertyTrace{}).SetupWebhookWithManager(mgr); err != nil {
+		return 1, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		if err := mgr.Start(ctx); err != nil {
+			fmt.Println("Failed to start manager:", err)
+		}
+	}()
+
+	k8sClient, err = client.New(cfg, client.Options{Scheme: scheme})
+	if err != nil {
+		return 1, err
+	}
+
+	// Wait for the webhook server to be ready
+	dialer := &net.Dialer{Timeout: time.Second}
+	addrPort := fmt.Sprintf("%s:%d", webhookInstallOptions.LocalServingHost, webhookInstallOptions.LocalServingPort)
+	for i := 0; ; i++ {
+		conn, err := tls.DialWithDialer(dialer, "tcp", addrPort, &tls.Config{InsecureSkipVerify: true})
+		if err == nil {
+			conn.Close()
+			break
+		}
+		if i == 20 {
+			return 1, fmt.Errorf("Webhook server is not ready: %v", err)
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+
+	return m.Run(), nil
+}
+
+func TestWebSphereLibertyApplicationWebhook(t *testing.T) {
+	newApp := func(name string, spec WebSphereLibertyApplicationSpec) *WebSphereLibertyApplication {
+		spec.ApplicationImage = "my-image"
+		spec.License = WebSphereLibertyApplicationLicense{Accept: true}
+		return &WebSphereLibertyApplication{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec:       spec,
+		}
+	}
+	knative := true
+
+	invalid := []struct {
+		name    string
+		app     *WebSphereLibertyApplication
+		message string
+	}{
+		{"license not accepted", &WebSphereLibertyApplication{
+			ObjectMeta: metav1.ObjectMeta{Name: "license", Namespace: namespace},
+			Spec:       WebSphereLibertyApplicationSpec{ApplicationImage: "my-image"},
+		}, "License not accepted"},
+		{"serviceability without size or claim", newApp("serviceability", WebSphereLibertyApplicationSpec{
+			Serviceability: &WebSphereLibertyApplicationServiceability{},
+		}), "Invalid input for Serviceability"},
+		{"statefulSet and Knative", newApp("knative", WebSphereLibertyApplicationSpec{
+			StatefulSet:          &WebSphereLibertyApplicationStatefulSet{},
+			CreateKnativeService: &knative,
+		}), "Invalid input for StatefulSet"},
+		{"storage without size", newApp("storage", WebSphereLibertyApplicationSpec{
+			StatefulSet: &WebSphereLibertyApplicationStatefulSet{Storage: &WebSphereLibertyApplicationStorage{}},
+		}), "spec.statefulSet.storage.size"},
+		{"transaction recovery and autoscaling", newApp("recovery", WebSphereLibertyApplicationSpec{
+			StatefulSet: &WebSphereLibertyApplicationStatefulSet{
+				Storage:             &WebSphereLibertyApplicationStorage{Size: "1Gi"},
+				TransactionRecovery: &WebSphereLibertyApplicationTransactionRecovery{},
+			},
+			Autoscaling: &WebSphereLibertyApplicationAutoScaling{MaxReplicas: 3},
+		}), "Invalid input for TransactionRecovery"},
+	}
+	for _, tt := range invalid {
+		err := k8sClient.Create(context.TODO(), tt.app)
+		if err == nil || !strings.Contains(err.Error(), tt.message) {
+			t.Errorf("%s: expected an error containing %q, got %v", tt.name, tt.message, err)
+		}
+	}
+
+	app := newApp("valid", WebSphereLibertyApplicationSpec{})
+	if err := k8sClient.Create(context.TODO(), app); err != nil {
+		t.Fatalf("valid application: %v", err)
+	}
+	if app.Spec.Service == nil || app.Spec.Service.Port != 9080 || app.Spec.License.Edition != LicenseEditionBase {
+		t.Errorf("defaulting: expected the defaults to be set, got %+v", app.Spec)
+	}
+	// The defaults of the operator configuration are not persisted
+	if app.Spec.PullPolicy != nil {
+		t.Errorf("defaulting: expected the pull policy not to be set, got %v", *app.Spec.PullPolicy)
+	}
+
+	app.Spec.Serviceability = &WebSphereLibertyApplicationServiceability{Size: "not-a-size"}
+	if err := k8sClient.Update(context.TODO(), app); err == nil || !strings.Contains(err.Error(), "cannot parse") {
+		t.Errorf("invalid update: expected an error, got %v", err)
+	}
+
+	// The license can not be withdrawn once it is accepted
+	app.Spec.Serviceability = nil
+	app.Spec.License.Accept = false
+	if err := k8sClient.Update(context.TODO(), app); err == nil || !strings.Contains(err.Error(), "License not accepted") {
+		t.Errorf("license withdrawal: expected an error, got %v", err)
+	}
+}
+
+func TestWebSphereLibertyDumpAndTraceWebhooks(t *testing.T) {
+	dump := &WebSphereLibertyDump{
+		ObjectMeta: metav1.ObjectMeta{Name: "dump", Namespace: namespace},
+		Spec:       WebSphereLibertyDumpSpec{PodName: "missing-pod"},
+	}
+	if err := k8sClient.Create(context.TODO(), dump); err == nil || !strings.Contains(err.Error(), "Pod missing-pod was not found") {
+		t.Errorf("dump of a missing pod: expected an error, got %v", err)
+	}
+
+	trace := &WebSphereLibertyTrace{
+		ObjectMeta: metav1.ObjectMeta{Name: "trace", Namespace: namespace},
+		Spec:       WebSphereLibertyTraceSpec{PodName: "missing-pod", TraceSpecification: "*=info"},
+	}
+	if err := k8sClient.Create(context.TODO(), trace); err == nil || !strings.Contains(err.Error(), "Pod missing-pod was not found") {
+		t.Errorf("trace of a missing pod: expected an error, got %v", err)
+	}
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "app-pod", Namespace: namespace},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: "my-image"}}},
+	}
+	if err := k8sClient.Create(context.TODO(), pod); err != nil {
+		t.Fatalf("pod: %v", err)
+	}
+
+	dump.Spec.PodName = pod.Name
+	if err := k8sClient.Create(context.TODO(), dump); err != nil {
+		t.Errorf("dump of an existing pod: %v", err)
+	}
+
+	trace.Spec.PodName = pod.Name
+	if err := k8sClient.Create(context.TODO(), trace); err != nil {
+		t.Fatalf("trace of an existing pod: %v", err)
+	}
+	if trace.Spec.Disable == nil || *trace.Spec.Disable {
+		t.Errorf("defaulting: expected spec.disable to be false, got %v", trace.Spec.Disable)
+	}
+
+	maxFiles := int32(-1)
+	trace.Spec.MaxFiles = &maxFiles
+	if err := k8sClient.Update(context.TODO(), trace); err == nil || !strings.Contains(err.Error(), "Invalid input for MaxFiles") {
+		t.Errorf("trace with negative maxFiles: expected an error, got %v", err)
+	}
+}
@@ -0,0 +1,5 @@
+package v1
+
+// Hub marks v1 as the version that the other versions of WebSphereLibertyApplication are converted to and from.
+// It is the storage version and the version reconciled by the operator.
+func (*WebSphereLibertyApplication) Hub() {}
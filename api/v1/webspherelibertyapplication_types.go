@@ -7,8 +7,11 @@ import (
 	prometheusv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
 	routev1 "github.com/openshift/api/route/v1"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
@@ -68,19 +71,26 @@ type WebSphereLibertyApplicationSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:order=41,type=spec,displayName="Route"
 	Route *WebSphereLibertyApplicationRoute `json:"route,omitempty"`
 
+	// +operator-sdk:csv:customresourcedefinitions:order=112,type=spec,displayName="Ingress"
+	Ingress *WebSphereLibertyApplicationIngress `json:"ingress,omitempty"`
+
 	// A boolean to toggle the creation of Knative resources and usage of Knative serving.
 	// +operator-sdk:csv:customresourcedefinitions:order=52,type=spec,displayName="Create Knative Service",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
 	CreateKnativeService *bool `json:"createKnativeService,omitempty"`
 
-	// Detects if the services need to be restarted.
+	// Generates the probes that are not specified on the MicroProfile Health endpoints of Liberty, and merges the specified probes with them. The server must enable the mpHealth feature. Defaults to false, unless default probes are set in the operator configuration.
+	// +operator-sdk:csv:customresourcedefinitions:order=127,type=spec,displayName="Default Health Probes",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	DefaultHealthProbes *bool `json:"defaultHealthProbes,omitempty"`
+
+	// Detects if the services need to be restarted. With spec.defaultHealthProbes, defaults to an HTTP probe on the /health/live endpoint and specified fields override the defaults.
 	// +operator-sdk:csv:customresourcedefinitions:order=53,type=spec,displayName="Liveness Probe"
 	LivenessProbe *corev1.Probe `json:"livenessProbe,omitempty"`
 
-	// Detects if the services are ready to serve.
+	// Detects if the services are ready to serve. With spec.defaultHealthProbes, defaults to an HTTP probe on the /health/ready endpoint and specified fields override the defaults.
 	// +operator-sdk:csv:customresourcedefinitions:order=54,type=spec,displayName="Readiness Probe"
 	ReadinessProbe *corev1.Probe `json:"readinessProbe,omitempty"`
 
-	// Protects slow starting containers from livenessProbe.
+	// Protects slow starting containers from livenessProbe. With spec.defaultHealthProbes, defaults to an HTTP probe on the /health/ready endpoint, which all versions of mpHealth serve, and specified fields override the defaults.
 	// +operator-sdk:csv:customresourcedefinitions:order=55,type=spec,displayName="Startup Probe"
 	StartupProbe *corev1.Probe `json:"startupProbe,omitempty"`
 
@@ -129,8 +139,101 @@ type WebSphereLibertyApplicationSpec struct {
 
 	// +operator-sdk:csv:customresourcedefinitions:order=30,type=spec,displayName="Single sign-on"
 	SSO *WebSphereLibertyApplicationSSO `json:"sso,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:order=31,type=spec,displayName="Server Configuration"
+	ServerConfig *WebSphereLibertyApplicationServerConfig `json:"serverConfig,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:order=32,type=spec,displayName="License"
+	License WebSphereLibertyApplicationLicense `json:"license,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:order=64,type=spec,displayName="Network Policy"
+	NetworkPolicy *WebSphereLibertyApplicationNetworkPolicy `json:"networkPolicy,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:order=68,type=spec,displayName="Disruption Budget"
+	DisruptionBudget *WebSphereLibertyApplicationDisruptionBudget `json:"disruptionBudget,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:order=72,type=spec,displayName="Semeru Cloud Compiler"
+	SemeruCloudCompiler *WebSphereLibertyApplicationSemeruCloudCompiler `json:"semeruCloudCompiler,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:order=75,type=spec,displayName="InstantOn"
+	InstantOn *WebSphereLibertyApplicationInstantOn `json:"instantOn,omitempty"`
+
+	// Security context of the application container. With InstantOn, the capabilities needed to restore the checkpoint are added to it,
+	// and the container must not run privileged or as the root user.
+	// +operator-sdk:csv:customresourcedefinitions:order=126,type=spec,displayName="Security Context"
+	SecurityContext *corev1.SecurityContext `json:"securityContext,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:order=78,type=spec,displayName="Session Cache"
+	SessionCache *WebSphereLibertyApplicationSessionCache `json:"sessionCache,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:order=87,type=spec,displayName="Rollout"
+	Rollout *WebSphereLibertyApplicationRollout `json:"rollout,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:order=104,type=spec,displayName="Gateway Route"
+	GatewayRoute *WebSphereLibertyApplicationGatewayRoute `json:"gatewayRoute,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:order=116,type=spec,displayName="Service Mesh"
+	ServiceMesh *WebSphereLibertyApplicationServiceMesh `json:"serviceMesh,omitempty"`
+}
+
+// Specifies the WebSphere Liberty license and entitlement used to report product usage.
+type WebSphereLibertyApplicationLicense struct {
+	// Product edition. Defaults to IBM WebSphere Application Server. Other options: IBM WebSphere Application Server Liberty Core, IBM WebSphere Application Server Network Deployment.
+	// +operator-sdk:csv:customresourcedefinitions:order=100,type=spec,displayName="Edition"
+	Edition LicenseEdition `json:"edition,omitempty"`
+
+	// Entitlement source for the product. Defaults to Standalone. Other options: IBM Cloud Pak for Applications, IBM WebSphere Application Server Family Edition, IBM WebSphere Hybrid Edition.
+	// +operator-sdk:csv:customresourcedefinitions:order=101,type=spec,displayName="Product Entitlement Source"
+	ProductEntitlementSource LicenseEntitlement `json:"productEntitlementSource,omitempty"`
+
+	// Charge metric code. Defaults to Virtual Processor Core (VPC). Other option: Processor Value Unit (PVU).
+	// +operator-sdk:csv:customresourcedefinitions:order=102,type=spec,displayName="Metric"
+	Metric LicenseMetric `json:"metric,omitempty"`
+
+	// I represent that the software in the above-referenced application container includes the IBM Program referenced below and I accept the terms of the license agreement corresponding to this IBM Program. The license agreement can be found at https://ibm.biz/was-license. Required to create an application. Existing applications that do not accept the license are still reconciled and report a LicenseAccepted condition of False.
+	// +operator-sdk:csv:customresourcedefinitions:order=103,type=spec,displayName="Accept License",xDescriptors="urn:alm:descriptor:com.tectonic.ui:checkbox"
+	Accept bool `json:"accept,omitempty"`
 }
 
+// Defines the possible values for product edition
+// +kubebuilder:validation:Enum=IBM WebSphere Application Server;IBM WebSphere Application Server Liberty Core;IBM WebSphere Application Server Network Deployment
+type LicenseEdition string
+
+const (
+	// LicenseEditionBase IBM WebSphere Application Server
+	LicenseEditionBase LicenseEdition = "IBM WebSphere Application Server"
+	// LicenseEditionCore IBM WebSphere Application Server Liberty Core
+	LicenseEditionCore LicenseEdition = "IBM WebSphere Application Server Liberty Core"
+	// LicenseEditionND IBM WebSphere Application Server Network Deployment
+	LicenseEditionND LicenseEdition = "IBM WebSphere Application Server Network Deployment"
+)
+
+// Defines the possible values for product entitlement source
+// +kubebuilder:validation:Enum=Standalone;IBM Cloud Pak for Applications;IBM WebSphere Application Server Family Edition;IBM WebSphere Hybrid Edition
+type LicenseEntitlement string
+
+const (
+	// LicenseEntitlementStandalone Standalone
+	LicenseEntitlementStandalone LicenseEntitlement = "Standalone"
+	// LicenseEntitlementCP4Apps IBM Cloud Pak for Applications
+	LicenseEntitlementCP4Apps LicenseEntitlement = "IBM Cloud Pak for Applications"
+	// LicenseEntitlementFamilyEdition IBM WebSphere Application Server Family Edition
+	LicenseEntitlementFamilyEdition LicenseEntitlement = "IBM WebSphere Application Server Family Edition"
+	// LicenseEntitlementWSHE IBM WebSphere Hybrid Edition
+	LicenseEntitlementWSHE LicenseEntitlement = "IBM WebSphere Hybrid Edition"
+)
+
+// Defines the possible values for charge metric codes
+// +kubebuilder:validation:Enum=Virtual Processor Core (VPC);Processor Value Unit (PVU)
+type LicenseMetric string
+
+const (
+	// LicenseMetricVPC Virtual Processor Core (VPC)
+	LicenseMetricVPC LicenseMetric = "Virtual Processor Core (VPC)"
+	// LicenseMetricPVU Processor Value Unit (PVU)
+	LicenseMetricPVU LicenseMetric = "Processor Value Unit (PVU)"
+)
+
 // Configures a Pod to run on particular Nodes.
 type WebSphereLibertyApplicationAffinity struct {
 	// Controls which nodes the pod are scheduled to run on, based on labels on the node.
@@ -152,11 +255,18 @@ type WebSphereLibertyApplicationAffinity struct {
 	// An array of architectures to be considered for deployment. Their position in the array indicates preference.
 	// +listType=set
 	Architecture []string `json:"architecture,omitempty"`
+
+	// Describes how pods are spread across topology domains. Defaults to spreading the pods across zones and nodes, unless disabled in the operator ConfigMap.
+	// +listType=map
+	// +listMapKey=topologyKey
+	// +listMapKey=whenUnsatisfiable
+	// +operator-sdk:csv:customresourcedefinitions:order=71,type=spec,displayName="Topology Spread Constraints"
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
 }
 
-// Configures the desired resource consumption of pods.
+// Configures the desired resource consumption of pods. The HorizontalPodAutoscaler is created with the autoscaling/v1 API when only CPU utilization is targeted. Memory utilization, metrics and behavior require the autoscaling/v2beta2 API, which is removed in Kubernetes 1.26.
 type WebSphereLibertyApplicationAutoScaling struct {
-	// Required field for autoscaling. Upper limit for the number of pods that can be set by the autoscaler. Parameter spec.resourceConstraints.requests.cpu must also be specified.
+	// Required field for autoscaling. Upper limit for the number of pods that can be set by the autoscaler. Parameter spec.resourceConstraints.requests.cpu must also be specified unless only other metrics are targeted.
 	// +kubebuilder:validation:Minimum=1
 	// +operator-sdk:csv:customresourcedefinitions:order=17,type=spec,displayName="Max Replicas",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
 	MaxReplicas int32 `json:"maxReplicas,omitempty"`
@@ -168,6 +278,19 @@ type WebSphereLibertyApplicationAutoScaling struct {
 	// Target average CPU utilization (represented as a percentage of requested CPU) over all the pods.
 	// +operator-sdk:csv:customresourcedefinitions:order=19,type=spec,displayName="Target CPU Utilization Percentage",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
 	TargetCPUUtilizationPercentage *int32 `json:"targetCPUUtilizationPercentage,omitempty"`
+
+	// Target average memory utilization (represented as a percentage of requested memory) over all the pods. Parameter spec.resourceConstraints.requests.memory must also be specified.
+	// +operator-sdk:csv:customresourcedefinitions:order=93,type=spec,displayName="Target Memory Utilization Percentage",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	TargetMemoryUtilizationPercentage *int32 `json:"targetMemoryUtilizationPercentage,omitempty"`
+
+	// Additional Pods, Object or External metrics to scale on, for example the request rate of the Liberty servers reported through a custom metrics adapter. Follows the autoscaling/v2beta2 MetricSpec schema.
+	// +listType=atomic
+	// +operator-sdk:csv:customresourcedefinitions:order=94,type=spec,displayName="Metrics"
+	Metrics []autoscalingv2beta2.MetricSpec `json:"metrics,omitempty"`
+
+	// Scaling policies and stabilization windows for scaling up and scaling down. Follows the autoscaling/v2beta2 HorizontalPodAutoscalerBehavior schema.
+	// +operator-sdk:csv:customresourcedefinitions:order=95,type=spec,displayName="Behavior"
+	Behavior *autoscalingv2beta2.HorizontalPodAutoscalerBehavior `json:"behavior,omitempty"`
 }
 
 // Configures parameters for the network service of pods.
@@ -233,6 +356,16 @@ type WebSphereLibertyApplicationStatefulSet struct {
 
 	// Annotations to be added only to the StatefulSet and resources owned by the StatefulSet.
 	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:order=85,type=spec,displayName="Transaction Recovery"
+	TransactionRecovery *WebSphereLibertyApplicationTransactionRecovery `json:"transactionRecovery,omitempty"`
+}
+
+// Persists the transaction logs of each pod on its own persisted storage and recovers the in-doubt transactions of a pod before it is removed on scale down.
+type WebSphereLibertyApplicationTransactionRecovery struct {
+	// The Liberty recovery group of the pods. Defaults to the name of the application.
+	// +operator-sdk:csv:customresourcedefinitions:order=86,type=spec,displayName="Recovery Group",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	RecoveryGroup string `json:"recoveryGroup,omitempty"`
 }
 
 // Defines settings of persisted storage for StatefulSets.
@@ -261,6 +394,16 @@ type WebSphereLibertyApplicationMonitoring struct {
 	// +listType=atomic
 	// +operator-sdk:csv:customresourcedefinitions:order=35,type=spec,displayName="Monitoring Endpoints",xDescriptors="urn:alm:descriptor:com.tectonic.ui:endpointList"
 	Endpoints []prometheusv1.Endpoint `json:"endpoints,omitempty"`
+
+	// The name of a Secret with the username and password keys of a Liberty user in the administrator-role or reader-role, used by Prometheus to read the /metrics endpoint.
+	// If not specified, the operator generates the credentials and adds the user to the server configuration with a basicRegistry and the reader-role.
+	// +operator-sdk:csv:customresourcedefinitions:order=77,type=spec,displayName="Credentials Secret Reference",xDescriptors="urn:alm:descriptor:io.kubernetes:Secret"
+	CredentialsSecretRef *string `json:"credentialsSecretRef,omitempty"`
+
+	// Generate the credentials of the metrics user when credentialsSecretRef is not specified. Set to false when the application configures its own user registry,
+	// which the generated basicRegistry would conflict with. Prometheus then reads the /metrics endpoint without credentials. Defaults to true.
+	// +operator-sdk:csv:customresourcedefinitions:order=128,type=spec,displayName="Generate Credentials",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	GenerateCredentials *bool `json:"generateCredentials,omitempty"`
 }
 
 // Specifies serviceability-related operations, such as gathering server memory dumps and server traces.
@@ -278,6 +421,320 @@ type WebSphereLibertyApplicationServiceability struct {
 	StorageClassName string `json:"storageClassName,omitempty"`
 }
 
+// Specifies ConfigMaps and Secrets that hold Liberty server configuration snippets. Every key ending in .xml is mounted as a file into the configDropins directories of the server.
+type WebSphereLibertyApplicationServerConfig struct {
+	// Sources mounted into /config/configDropins/overrides. Configuration in these files overrides server.xml.
+	// +listType=atomic
+	Overrides []WebSphereLibertyApplicationServerConfigSource `json:"overrides,omitempty"`
+
+	// Sources mounted into /config/configDropins/defaults. Configuration in these files is overridden by server.xml.
+	// +listType=atomic
+	Defaults []WebSphereLibertyApplicationServerConfigSource `json:"defaults,omitempty"`
+}
+
+// Represents a ConfigMap or a Secret with Liberty server configuration. Specify exactly one of configMapName and secretName.
+type WebSphereLibertyApplicationServerConfigSource struct {
+	// The name of a ConfigMap in the same namespace as the WebSphereLibertyApplication.
+	ConfigMapName string `json:"configMapName,omitempty"`
+
+	// The name of a Secret in the same namespace as the WebSphereLibertyApplication.
+	SecretName string `json:"secretName,omitempty"`
+}
+
+// Restricts incoming traffic to the service ports of the application pods with a NetworkPolicy.
+type WebSphereLibertyApplicationNetworkPolicy struct {
+	// Labels of the namespaces that incoming traffic is allowed from. Defaults to the namespace of the OpenShift router, or on Kubernetes to the namespace set in the networkPolicyIngressNamespace key of the operator ConfigMap. Traffic from other namespaces is not allowed by default if that key is not set.
+	// +operator-sdk:csv:customresourcedefinitions:order=65,type=spec,displayName="Namespace Labels",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	NamespaceLabels map[string]string `json:"namespaceLabels,omitempty"`
+
+	// Labels of the pods in the same namespace that incoming traffic is allowed from. Defaults to the pods with the same applicationName.
+	// +operator-sdk:csv:customresourcedefinitions:order=66,type=spec,displayName="From Labels",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	FromLabels map[string]string `json:"fromLabels,omitempty"`
+
+	// Additional peers that incoming traffic is allowed from.
+	// +listType=atomic
+	// +operator-sdk:csv:customresourcedefinitions:order=67,type=spec,displayName="From"
+	From []networkingv1.NetworkPolicyPeer `json:"from,omitempty"`
+}
+
+// Configures the PodDisruptionBudget of the application. A PodDisruptionBudget is only created when the application runs more than one replica.
+// If neither minAvailable nor maxUnavailable is set, minAvailable defaults to one less than spec.replicas or spec.autoscaling.minReplicas.
+type WebSphereLibertyApplicationDisruptionBudget struct {
+	// The minimum number or percentage of pods that must remain available during voluntary disruptions. Cannot be used together with maxUnavailable.
+	// +operator-sdk:csv:customresourcedefinitions:order=69,type=spec,displayName="Min Available",xDescriptors="urn:alm:descriptor:com.tectonic.ui:podCount"
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+
+	// The maximum number or percentage of pods that can be unavailable during voluntary disruptions. Cannot be used together with minAvailable.
+	// +operator-sdk:csv:customresourcedefinitions:order=70,type=spec,displayName="Max Unavailable",xDescriptors="urn:alm:descriptor:com.tectonic.ui:podCount"
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+// Deploys a Semeru Cloud Compiler (JITServer) next to the application, using the image of the application, and offloads JIT compilation of the application to it.
+type WebSphereLibertyApplicationSemeruCloudCompiler struct {
+	// Number of desired pods for the Semeru Cloud Compiler. Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:order=73,type=spec,displayName="Replicas",xDescriptors="urn:alm:descriptor:com.tectonic.ui:podCount"
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Resource requests and limits for the Semeru Cloud Compiler. The CPU defaults to 100m with a limit of 2000m. The memory defaults to 800Mi, with a limit of 1200Mi.
+	// +operator-sdk:csv:customresourcedefinitions:order=74,type=spec,displayName="Resource Requirements",xDescriptors="urn:alm:descriptor:com.tectonic.ui:resourceRequirements"
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// Runs the application from a Liberty InstantOn image, restoring the server from the checkpoint in the image.
+// The application container is granted the CHECKPOINT_RESTORE and SETPCAP capabilities. With Knative, the cluster must allow adding capabilities.
+type WebSphereLibertyApplicationInstantOn struct {
+	// JVM options that apply when the server is restored from the checkpoint.
+	// +operator-sdk:csv:customresourcedefinitions:order=76,type=spec,displayName="Restore Java Options",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	RestoreJavaOptions string `json:"restoreJavaOptions,omitempty"`
+}
+
+// Stores the HTTP sessions of the application in a distributed JCache provider, so that sessions survive pod restarts and rolling updates.
+// The application image must include the JCache client libraries of the provider.
+type WebSphereLibertyApplicationSessionCache struct {
+	// The product that stores the HTTP sessions.
+	// +operator-sdk:csv:customresourcedefinitions:order=79,type=spec,displayName="Provider",xDescriptors="urn:alm:descriptor:com.tectonic.ui:select:Infinispan,urn:alm:descriptor:com.tectonic.ui:select:Hazelcast"
+	Provider SessionCacheProvider `json:"provider"`
+
+	// Comma-separated list of host:port addresses of the servers of an existing cache cluster. Required unless provision is set.
+	// +operator-sdk:csv:customresourcedefinitions:order=80,type=spec,displayName="Server List",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	ServerList string `json:"serverList,omitempty"`
+
+	// The name of a Secret with the username and password keys used to connect to an existing Infinispan cluster.
+	// +operator-sdk:csv:customresourcedefinitions:order=81,type=spec,displayName="Credentials Secret Reference",xDescriptors="urn:alm:descriptor:io.kubernetes:Secret"
+	CredentialsSecretRef *string `json:"credentialsSecretRef,omitempty"`
+
+	// Provision an Infinispan cluster for the application with the Infinispan Operator. Only supported with the Infinispan provider.
+	// +operator-sdk:csv:customresourcedefinitions:order=82,type=spec,displayName="Provision",xDescriptors="urn:alm:descriptor:com.tectonic.ui:booleanSwitch"
+	Provision bool `json:"provision,omitempty"`
+
+	// Number of pods of the provisioned Infinispan cluster. Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:order=83,type=spec,displayName="Replicas",xDescriptors="urn:alm:descriptor:com.tectonic.ui:podCount"
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Name of the Hazelcast cluster. Defaults to dev.
+	// +operator-sdk:csv:customresourcedefinitions:order=84,type=spec,displayName="Cluster Name",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	ClusterName string `json:"clusterName,omitempty"`
+}
+
+// Defines the possible values for session cache providers
+// +kubebuilder:validation:Enum=Infinispan;Hazelcast
+type SessionCacheProvider string
+
+const (
+	// SessionCacheProviderInfinispan Infinispan
+	SessionCacheProviderInfinispan SessionCacheProvider = "Infinispan"
+	// SessionCacheProviderHazelcast Hazelcast
+	SessionCacheProviderHazelcast SessionCacheProvider = "Hazelcast"
+)
+
+// Rolls out a new application image in a separate Deployment and Service, and shifts the traffic of the Route or Ingress to it before
+// the application Deployment is updated. Specify exactly one of canary or blueGreen. Only supported with a Deployment.
+// The traffic of an Ingress is shifted with the canary annotations of the NGINX ingress controller, so the ingress class must be nginx if it is set.
+// The selector of a Deployment created before the rollout strategy was specified cannot be changed, so its autoscaler also counts the pods of the new image.
+type WebSphereLibertyApplicationRollout struct {
+	// +operator-sdk:csv:customresourcedefinitions:order=88,type=spec,displayName="Canary"
+	Canary *WebSphereLibertyApplicationCanary `json:"canary,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:order=90,type=spec,displayName="Blue-Green"
+	BlueGreen *WebSphereLibertyApplicationBlueGreen `json:"blueGreen,omitempty"`
+
+	// The number of seconds for the pods of the new image to be ready before the rollout is aborted. Defaults to 600.
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:order=92,type=spec,displayName="Progress Deadline Seconds",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	ProgressDeadlineSeconds *int32 `json:"progressDeadlineSeconds,omitempty"`
+}
+
+// Gradually shifts the traffic to the new image, one step at a time, and promotes the new image after the last step.
+type WebSphereLibertyApplicationCanary struct {
+	// The steps of the rollout. Defaults to 20% of the traffic for 5 minutes, then 50% of the traffic for 5 minutes.
+	// +listType=atomic
+	// +operator-sdk:csv:customresourcedefinitions:order=89,type=spec,displayName="Steps"
+	Steps []WebSphereLibertyApplicationCanaryStep `json:"steps,omitempty"`
+}
+
+// Defines a step of a canary rollout.
+type WebSphereLibertyApplicationCanaryStep struct {
+	// Percentage of the traffic sent to the new image.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	Weight int32 `json:"weight"`
+
+	// How long the traffic is sent to the new image before the next step, for example 5m. Defaults to no pause.
+	Pause *metav1.Duration `json:"pause,omitempty"`
+}
+
+// Runs the new image without traffic, reachable through the preview Service, and switches all the traffic to it once its pods are ready.
+type WebSphereLibertyApplicationBlueGreen struct {
+	// How long the pods of the new image are kept ready without traffic before the traffic is switched, for example 10m. Defaults to no delay.
+	// +operator-sdk:csv:customresourcedefinitions:order=91,type=spec,displayName="Promotion Delay",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	PromotionDelay *metav1.Duration `json:"promotionDelay,omitempty"`
+}
+
+// Exposes the application through a Gateway API HTTPRoute attached to a Gateway, instead of a Route or an Ingress, when expose is enabled.
+// TLS is terminated by the listener of the Gateway that the HTTPRoute is attached to.
+type WebSphereLibertyApplicationGatewayRoute struct {
+	// +operator-sdk:csv:customresourcedefinitions:order=105,type=spec,displayName="Gateway"
+	Gateway WebSphereLibertyApplicationGatewayReference `json:"gateway"`
+
+	// Hostnames matched by the HTTPRoute. Defaults to the host of spec.route, or to the hostnames of the Gateway listeners.
+	// +listType=set
+	// +operator-sdk:csv:customresourcedefinitions:order=109,type=spec,displayName="Hostnames",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Hostnames []string `json:"hostnames,omitempty"`
+
+	// Requests that are sent to the application. Defaults to the requests with a path that starts with the path of spec.route, or to all requests.
+	// +listType=atomic
+	// +operator-sdk:csv:customresourcedefinitions:order=110,type=spec,displayName="Matches"
+	Matches []WebSphereLibertyApplicationGatewayRouteMatch `json:"matches,omitempty"`
+
+	// Annotations to be added to the HTTPRoute.
+	// +operator-sdk:csv:customresourcedefinitions:order=111,type=spec,displayName="Annotations",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// Identifies the Gateway, and optionally one of its listeners, that the HTTPRoute is attached to.
+type WebSphereLibertyApplicationGatewayReference struct {
+	// Name of the Gateway.
+	// +operator-sdk:csv:customresourcedefinitions:order=106,type=spec,displayName="Gateway Name",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Name string `json:"name"`
+
+	// Namespace of the Gateway. Defaults to the namespace of the application.
+	// +operator-sdk:csv:customresourcedefinitions:order=107,type=spec,displayName="Gateway Namespace",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Namespace string `json:"namespace,omitempty"`
+
+	// Name of the listener of the Gateway, for example its HTTPS listener. Defaults to all the listeners that accept the HTTPRoute.
+	// +operator-sdk:csv:customresourcedefinitions:order=108,type=spec,displayName="Listener Name",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	SectionName string `json:"sectionName,omitempty"`
+}
+
+// Matches requests on their path and headers. A request must meet all the conditions of the match.
+type WebSphereLibertyApplicationGatewayRouteMatch struct {
+	// Path of the requests. Defaults to /.
+	Path string `json:"path,omitempty"`
+
+	// How the path of the requests is matched. Defaults to PathPrefix.
+	// +kubebuilder:validation:Enum=PathPrefix;Exact;RegularExpression
+	PathType string `json:"pathType,omitempty"`
+
+	// HTTP headers of the requests.
+	// +listType=atomic
+	Headers []WebSphereLibertyApplicationGatewayHeaderMatch `json:"headers,omitempty"`
+}
+
+// Matches an HTTP header of the requests.
+type WebSphereLibertyApplicationGatewayHeaderMatch struct {
+	// Name of the HTTP header.
+	Name string `json:"name"`
+
+	// Value of the HTTP header.
+	Value string `json:"value"`
+
+	// How the value of the HTTP header is matched. Defaults to Exact.
+	// +kubebuilder:validation:Enum=Exact;RegularExpression
+	Type string `json:"type,omitempty"`
+}
+
+// Configures the Ingress resource with several hosts and paths, instead of the single host and path of spec.route. Only used
+// when the cluster does not support Routes.
+type WebSphereLibertyApplicationIngress struct {
+	// Name of the IngressClass that implements the Ingress. Defaults to the default IngressClass of the cluster.
+	// +operator-sdk:csv:customresourcedefinitions:order=113,type=spec,displayName="Ingress Class Name",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	IngressClassName *string `json:"ingressClassName,omitempty"`
+
+	// Hosts and paths of the requests that are sent to the application.
+	// +listType=map
+	// +listMapKey=host
+	// +kubebuilder:validation:MinItems=1
+	// +operator-sdk:csv:customresourcedefinitions:order=114,type=spec,displayName="Rules"
+	Rules []WebSphereLibertyApplicationIngressRule `json:"rules"`
+
+	// Annotations to be added to the Ingress.
+	// +operator-sdk:csv:customresourcedefinitions:order=115,type=spec,displayName="Annotations",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// Sends the requests for a host to the application.
+type WebSphereLibertyApplicationIngressRule struct {
+	// Host of the requests.
+	Host string `json:"host"`
+
+	// Name of the Secret with the TLS certificate and key for the host. Defaults to spec.route.certificateSecretRef, which is
+	// issued for the host when cert-manager is configured. TLS is not enabled for the host if neither is specified.
+	TLSSecretName string `json:"tlsSecretName,omitempty"`
+
+	// Paths of the requests. Defaults to all the requests for the host, sent to spec.service.port.
+	// +listType=atomic
+	Paths []WebSphereLibertyApplicationIngressPath `json:"paths,omitempty"`
+}
+
+// Sends the requests for a path to a port of the application Service.
+type WebSphereLibertyApplicationIngressPath struct {
+	// Path of the requests. Defaults to /.
+	Path string `json:"path,omitempty"`
+
+	// How the path of the requests is matched. Defaults to Prefix.
+	// +kubebuilder:validation:Enum=Exact;Prefix;ImplementationSpecific
+	PathType *networkingv1.PathType `json:"pathType,omitempty"`
+
+	// Port of the application Service the requests are sent to, either spec.service.port or the port of one of spec.service.ports.
+	// Defaults to spec.service.port.
+	// +kubebuilder:validation:Maximum=65535
+	// +kubebuilder:validation:Minimum=1
+	Port int32 `json:"port,omitempty"`
+}
+
+// Integrates the application with the Istio service mesh. The pods run with an Istio sidecar, and a VirtualService, a
+// DestinationRule and a PeerAuthentication are created for the application.
+type WebSphereLibertyApplicationServiceMesh struct {
+	// Mutual TLS mode of the traffic to the application pods. STRICT only accepts mutual TLS traffic, PERMISSIVE also accepts
+	// plain text traffic, and DISABLE turns mutual TLS off. Defaults to PERMISSIVE. With STRICT, the clients outside the mesh, e.g.
+	// the Route or Ingress of spec.expose and the Prometheus scrapes of spec.monitoring, can no longer reach the application.
+	// +kubebuilder:validation:Enum=STRICT;PERMISSIVE;DISABLE
+	// +operator-sdk:csv:customresourcedefinitions:order=117,type=spec,displayName="Mutual TLS Mode",xDescriptors="urn:alm:descriptor:com.tectonic.ui:select:STRICT","urn:alm:descriptor:com.tectonic.ui:select:PERMISSIVE","urn:alm:descriptor:com.tectonic.ui:select:DISABLE"
+	MTLSMode string `json:"mtlsMode,omitempty"`
+
+	// Hosts of the VirtualService, in addition to the application Service.
+	// +listType=set
+	// +operator-sdk:csv:customresourcedefinitions:order=118,type=spec,displayName="Hosts",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Hosts []string `json:"hosts,omitempty"`
+
+	// Istio Gateways the VirtualService is bound to, in the namespace/name format, in addition to the sidecars of the mesh.
+	// +listType=set
+	// +operator-sdk:csv:customresourcedefinitions:order=119,type=spec,displayName="Gateways",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Gateways []string `json:"gateways,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:order=120,type=spec,displayName="Connection Pool"
+	ConnectionPool *WebSphereLibertyApplicationConnectionPool `json:"connectionPool,omitempty"`
+}
+
+// Limits the connections and requests that each client sidecar sends to the application.
+type WebSphereLibertyApplicationConnectionPool struct {
+	// Maximum number of TCP connections to the application.
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:order=121,type=spec,displayName="Max Connections",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	MaxConnections *int32 `json:"maxConnections,omitempty"`
+
+	// TCP connection timeout, for example 5s.
+	// +operator-sdk:csv:customresourcedefinitions:order=122,type=spec,displayName="Connect Timeout",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	ConnectTimeout *metav1.Duration `json:"connectTimeout,omitempty"`
+
+	// Maximum number of requests waiting for a connection to the application.
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:order=123,type=spec,displayName="Max Pending Requests",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	MaxPendingRequests *int32 `json:"maxPendingRequests,omitempty"`
+
+	// Maximum number of active requests to the application.
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:order=124,type=spec,displayName="Max Requests",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	MaxRequests *int32 `json:"maxRequests,omitempty"`
+
+	// Maximum number of requests per connection to the application. Set it to 1 to disable keep-alive.
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:order=125,type=spec,displayName="Max Requests Per Connection",xDescriptors="urn:alm:descriptor:com.tectonic.ui:number"
+	MaxRequestsPerConnection *int32 `json:"maxRequestsPerConnection,omitempty"`
+}
+
 // Configures the ingress resource.
 type WebSphereLibertyApplicationRoute struct {
 
@@ -316,6 +773,87 @@ type WebSphereLibertyApplicationStatus struct {
 
 	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Service Binding Secret",xDescriptors="urn:alm:descriptor:io.kubernetes:Secret"
 	Binding *corev1.LocalObjectReference `json:"binding,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Semeru Compiler"
+	SemeruCompiler *SemeruCompilerStatus `json:"semeruCompiler,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Transaction Recovery"
+	TransactionRecovery *TransactionRecoveryStatus `json:"transactionRecovery,omitempty"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Rollout"
+	Rollout *RolloutStatus `json:"rollout,omitempty"`
+
+	// The generation of the WebSphereLibertyApplication last processed by the operator.
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Observed Generation"
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// The URL of the application exposed by the Route, Ingress or Knative Service.
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Endpoint",xDescriptors="urn:alm:descriptor:org.w3:link"
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// The number of desired pods of the application.
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Replicas",xDescriptors="urn:alm:descriptor:com.tectonic.ui:podCount"
+	Replicas int32 `json:"replicas,omitempty"`
+
+	// The number of ready pods of the application.
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Ready Replicas"
+	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
+}
+
+// Defines the observed state of the rollout of a new application image.
+type RolloutStatus struct {
+	// The image of the application Deployment.
+	StableImage string `json:"stableImage,omitempty"`
+
+	// The image being rolled out, or the image of the last rollout.
+	Image string `json:"image,omitempty"`
+
+	// The phase of the rollout.
+	Phase RolloutPhase `json:"phase,omitempty"`
+
+	// The index of the current canary step.
+	Step int32 `json:"step,omitempty"`
+
+	// Percentage of the traffic sent to the new image.
+	Weight int32 `json:"weight,omitempty"`
+
+	// The time the rollout started.
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// The time the pods of the new image were ready for the current step.
+	StepStartTime *metav1.Time `json:"stepStartTime,omitempty"`
+}
+
+// Defines the possible phases of a rollout.
+type RolloutPhase string
+
+const (
+	// RolloutPhaseProgressing the traffic is shifted to the new image
+	RolloutPhaseProgressing RolloutPhase = "Progressing"
+	// RolloutPhasePromoting all the traffic is switched to the new image while the application Deployment is updated
+	RolloutPhasePromoting RolloutPhase = "Promoting"
+	// RolloutPhaseCompleted the application Deployment runs the new image
+	RolloutPhaseCompleted RolloutPhase = "Completed"
+	// RolloutPhaseAborted the pods of the new image were not ready in time, and the application Deployment keeps its image
+	RolloutPhaseAborted RolloutPhase = "Aborted"
+)
+
+// Defines the observed state of the recovery of the transactions of a StatefulSet pod that is removed on scale down.
+type TransactionRecoveryStatus struct {
+	// The ordinal of the pod whose in-doubt transactions are recovered. Scaling is blocked until the recovery completes.
+	Ordinal int32 `json:"ordinal"`
+
+	// The name of the Job that recovers the transactions.
+	Job string `json:"job,omitempty"`
+}
+
+// Defines the observed state of the Semeru Cloud Compiler.
+type SemeruCompilerStatus struct {
+	// The host name of the Service of the Semeru Cloud Compiler.
+	ServiceHostname string `json:"serviceHostname,omitempty"`
+
+	// Whether all the pods of the Semeru Cloud Compiler are ready.
+	Ready bool `json:"ready"`
 }
 
 // Defines possible status conditions.
@@ -333,14 +871,31 @@ type StatusConditionType string
 const (
 	// StatusConditionTypeReconciled ...
 	StatusConditionTypeReconciled StatusConditionType = "Reconciled"
+
+	// StatusConditionTypeResourcesReady indicates whether the pods of the application are updated and ready
+	StatusConditionTypeResourcesReady StatusConditionType = "ResourcesReady"
+
+	// StatusConditionTypeReady indicates whether the application is reconciled and its resources are ready
+	StatusConditionTypeReady StatusConditionType = "Ready"
+
+	// StatusConditionTypePaused indicates whether the reconciliation of the application is paused
+	StatusConditionTypePaused StatusConditionType = "Paused"
+
+	// StatusConditionTypeLicenseAccepted indicates whether the license is accepted in spec.license.accept
+	StatusConditionTypeLicenseAccepted StatusConditionType = "LicenseAccepted"
 )
 
 // +kubebuilder:resource:path=webspherelibertyapplications,scope=Namespaced,shortName=wlapp;wlapps
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:storageversion
 // +kubebuilder:printcolumn:name="Image",type="string",JSONPath=".spec.applicationImage",priority=0,description="Absolute name of the deployed image containing registry and tag"
 // +kubebuilder:printcolumn:name="Exposed",type="boolean",JSONPath=".spec.expose",priority=0,description="Specifies whether deployment is exposed externally via default Route"
 // +kubebuilder:printcolumn:name="Reconciled",type="string",JSONPath=".status.conditions[?(@.type=='Reconciled')].status",priority=0,description="Status of the reconcile condition"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status",priority=0,description="Status of the ready condition"
+// +kubebuilder:printcolumn:name="Ready Replicas",type="integer",JSONPath=".status.readyReplicas",priority=0,description="Number of ready pods"
+// +kubebuilder:printcolumn:name="Replicas",type="integer",JSONPath=".status.replicas",priority=1,description="Number of desired pods"
+// +kubebuilder:printcolumn:name="Endpoint",type="string",JSONPath=".status.endpoint",priority=1,description="URL of the exposed application"
 // +kubebuilder:printcolumn:name="Reason",type="string",JSONPath=".status.conditions[?(@.type=='Reconciled')].reason",priority=1,description="Reason for the failure of reconcile condition"
 // +kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.conditions[?(@.type=='Reconciled')].message",priority=1,description="Failure message from reconcile condition"
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",priority=0,description="Age of the resource"
@@ -479,8 +1034,12 @@ func (cr *WebSphereLibertyApplication) GetApplicationImage() string {
 	return cr.Spec.ApplicationImage
 }
 
-// GetPullPolicy returns image pull policy
+// GetPullPolicy returns image pull policy, or the default pull policy of the operator configuration if it is not set
 func (cr *WebSphereLibertyApplication) GetPullPolicy() *corev1.PullPolicy {
+	if cr.Spec.PullPolicy == nil {
+		pp := GetOperatorDefaults().PullPolicy
+		return &pp
+	}
 	return cr.Spec.PullPolicy
 }
 
@@ -499,19 +1058,116 @@ func (cr *WebSphereLibertyApplication) GetReplicas() *int32 {
 	return cr.Spec.Replicas
 }
 
-// GetLivenessProbe returns liveness probe
+// GetLivenessProbe returns liveness probe, merged with the default probe on the Liberty liveness health endpoint and with the
+// default liveness probe of the operator configuration when the default health probes are enabled
 func (cr *WebSphereLibertyApplication) GetLivenessProbe() *corev1.Probe {
-	return cr.Spec.LivenessProbe
+	defaultProbe := cr.getDefaultHealthProbe("/health/live")
+	defaultProbe.InitialDelaySeconds = 60
+	defaultProbe.FailureThreshold = 3
+	return cr.getHealthProbe(defaultProbe, GetOperatorDefaults().LivenessProbe, cr.Spec.LivenessProbe)
 }
 
-// GetReadinessProbe returns readiness probe
+// GetReadinessProbe returns readiness probe, merged with the default probe on the Liberty readiness health endpoint and with the
+// default readiness probe of the operator configuration when the default health probes are enabled
 func (cr *WebSphereLibertyApplication) GetReadinessProbe() *corev1.Probe {
-	return cr.Spec.ReadinessProbe
+	defaultProbe := cr.getDefaultHealthProbe("/health/ready")
+	defaultProbe.InitialDelaySeconds = 10
+	defaultProbe.FailureThreshold = 10
+	return cr.getHealthProbe(defaultProbe, GetOperatorDefaults().ReadinessProbe, cr.Spec.ReadinessProbe)
 }
 
-// GetStartupProbe returns startup probe
+// GetStartupProbe returns startup probe, merged with the default probe on the Liberty readiness health endpoint and with the
+// default startup probe of the operator configuration when the default health probes are enabled. The /health/started endpoint
+// is only served from mpHealth-3.1, so the server is considered started once it is ready.
 func (cr *WebSphereLibertyApplication) GetStartupProbe() *corev1.Probe {
-	return cr.Spec.StartupProbe
+	defaultProbe := cr.getDefaultHealthProbe("/health/ready")
+	defaultProbe.FailureThreshold = 20
+	return cr.getHealthProbe(defaultProbe, GetOperatorDefaults().StartupProbe, cr.Spec.StartupProbe)
+}
+
+// getHealthProbe returns the probe of the spec as is, unless spec.defaultHealthProbes is true or the operator configuration sets
+// a default probe. The probe is then merged with the default probe, and the default probe with the Liberty health probe.
+func (cr *WebSphereLibertyApplication) getHealthProbe(libertyProbe *corev1.Probe, defaultProbe *corev1.Probe, probe *corev1.Probe) *corev1.Probe {
+	enabled := defaultProbe != nil
+	if cr.Spec.DefaultHealthProbes != nil {
+		enabled = *cr.Spec.DefaultHealthProbes
+	}
+	if !enabled {
+		return probe
+	}
+	return mergeProbe(mergeProbe(libertyProbe, defaultProbe), probe)
+}
+
+// getDefaultHealthProbe returns a probe on a MicroProfile Health endpoint of Liberty, using the port and the scheme of the service
+func (cr *WebSphereLibertyApplication) getDefaultHealthProbe(path string) *corev1.Probe {
+	port := int32(9080)
+	scheme := corev1.URISchemeHTTP
+	if cr.Spec.Service != nil {
+		if cr.Spec.Service.Port != 0 {
+			port = cr.Spec.Service.Port
+		}
+		if cr.Spec.Service.TargetPort != nil {
+			port = *cr.Spec.Service.TargetPort
+		}
+		if cr.Spec.Service.CertificateSecretRef != nil {
+			scheme = corev1.URISchemeHTTPS
+		}
+	}
+	return &corev1.Probe{
+		Handler: corev1.Handler{
+			HTTPGet: &corev1.HTTPGetAction{
+				Path:   path,
+				Port:   intstr.FromInt(int(port)),
+				Scheme: scheme,
+			},
+		},
+		PeriodSeconds:  10,
+		TimeoutSeconds: 2,
+	}
+}
+
+// mergeProbe overrides the default probe with the fields that are set in the probe of the spec
+func mergeProbe(defaultProbe *corev1.Probe, probe *corev1.Probe) *corev1.Probe {
+	if probe == nil {
+		return defaultProbe
+	}
+
+	merged := defaultProbe
+	if probe.Exec != nil || probe.TCPSocket != nil || (probe.HTTPGet != nil && merged.HTTPGet == nil) {
+		merged.Handler = *probe.Handler.DeepCopy()
+	} else if probe.HTTPGet != nil {
+		if probe.HTTPGet.Path != "" {
+			merged.HTTPGet.Path = probe.HTTPGet.Path
+		}
+		if probe.HTTPGet.Port.String() != "0" {
+			merged.HTTPGet.Port = probe.HTTPGet.Port
+		}
+		if probe.HTTPGet.Host != "" {
+			merged.HTTPGet.Host = probe.HTTPGet.Host
+		}
+		if probe.HTTPGet.Scheme != "" {
+			merged.HTTPGet.Scheme = probe.HTTPGet.Scheme
+		}
+		if probe.HTTPGet.HTTPHeaders != nil {
+			merged.HTTPGet.HTTPHeaders = append([]corev1.HTTPHeader{}, probe.HTTPGet.HTTPHeaders...)
+		}
+	}
+	if probe.InitialDelaySeconds != 0 {
+		merged.InitialDelaySeconds = probe.InitialDelaySeconds
+	}
+	if probe.TimeoutSeconds != 0 {
+		merged.TimeoutSeconds = probe.TimeoutSeconds
+	}
+	if probe.PeriodSeconds != 0 {
+		merged.PeriodSeconds = probe.PeriodSeconds
+	}
+	if probe.SuccessThreshold != 0 {
+		merged.SuccessThreshold = probe.SuccessThreshold
+	}
+	if probe.FailureThreshold != 0 {
+		merged.FailureThreshold = probe.FailureThreshold
+	}
+	return merged
 }
 
 // GetVolumes returns volumes slice
@@ -524,9 +1180,14 @@ func (cr *WebSphereLibertyApplication) GetVolumeMounts() []corev1.VolumeMount {
 	return cr.Spec.VolumeMounts
 }
 
-// GetResourceConstraints returns resource constraints
+// GetResourceConstraints returns resource constraints, or the default resources of the operator configuration if the spec sets
+// neither requests nor limits
 func (cr *WebSphereLibertyApplication) GetResourceConstraints() *corev1.ResourceRequirements {
-	return cr.Spec.ResourceConstraints
+	rc := cr.Spec.ResourceConstraints
+	if defaults := GetOperatorDefaults().Resources; defaults != nil && (rc == nil || (len(rc.Requests) == 0 && len(rc.Limits) == 0)) {
+		return defaults.DeepCopy()
+	}
+	return rc
 }
 
 // GetExpose returns expose flag
@@ -565,6 +1226,11 @@ func (ss *WebSphereLibertyApplicationStatefulSet) GetStorage() common.BaseCompon
 	return ss.Storage
 }
 
+// GetTransactionRecovery returns transaction recovery settings
+func (ss *WebSphereLibertyApplicationStatefulSet) GetTransactionRecovery() *WebSphereLibertyApplicationTransactionRecovery {
+	return ss.TransactionRecovery
+}
+
 // GetService returns service settings
 func (cr *WebSphereLibertyApplication) GetService() common.BaseComponentService {
 	if cr.Spec.Service == nil {
@@ -733,6 +1399,76 @@ func (s *WebSphereLibertyApplicationServiceability) GetVolumeClaimName() string
 	return s.VolumeClaimName
 }
 
+// GetServerConfig returns server configuration sources
+func (cr *WebSphereLibertyApplication) GetServerConfig() *WebSphereLibertyApplicationServerConfig {
+	return cr.Spec.ServerConfig
+}
+
+// GetOverrides returns server configuration sources to be mounted into configDropins/overrides
+func (sc *WebSphereLibertyApplicationServerConfig) GetOverrides() []WebSphereLibertyApplicationServerConfigSource {
+	return sc.Overrides
+}
+
+// GetDefaults returns server configuration sources to be mounted into configDropins/defaults
+func (sc *WebSphereLibertyApplicationServerConfig) GetDefaults() []WebSphereLibertyApplicationServerConfigSource {
+	return sc.Defaults
+}
+
+// GetNetworkPolicy returns the network policy settings
+func (cr *WebSphereLibertyApplication) GetNetworkPolicy() *WebSphereLibertyApplicationNetworkPolicy {
+	return cr.Spec.NetworkPolicy
+}
+
+// GetDisruptionBudget returns the PodDisruptionBudget settings
+func (cr *WebSphereLibertyApplication) GetDisruptionBudget() *WebSphereLibertyApplicationDisruptionBudget {
+	return cr.Spec.DisruptionBudget
+}
+
+// GetSemeruCloudCompiler returns the Semeru Cloud Compiler settings
+func (cr *WebSphereLibertyApplication) GetSemeruCloudCompiler() *WebSphereLibertyApplicationSemeruCloudCompiler {
+	return cr.Spec.SemeruCloudCompiler
+}
+
+// GetInstantOn returns the InstantOn settings
+func (cr *WebSphereLibertyApplication) GetInstantOn() *WebSphereLibertyApplicationInstantOn {
+	return cr.Spec.InstantOn
+}
+
+// GetSecurityContext returns the security context of the application container
+func (cr *WebSphereLibertyApplication) GetSecurityContext() *corev1.SecurityContext {
+	return cr.Spec.SecurityContext
+}
+
+// GetSessionCache returns the HTTP session cache settings
+func (cr *WebSphereLibertyApplication) GetSessionCache() *WebSphereLibertyApplicationSessionCache {
+	return cr.Spec.SessionCache
+}
+
+// GetServiceMesh returns the Istio service mesh settings
+func (cr *WebSphereLibertyApplication) GetServiceMesh() *WebSphereLibertyApplicationServiceMesh {
+	return cr.Spec.ServiceMesh
+}
+
+// GetRollout returns the rollout strategy of new images
+func (cr *WebSphereLibertyApplication) GetRollout() *WebSphereLibertyApplicationRollout {
+	return cr.Spec.Rollout
+}
+
+// GetIngress returns the settings of the Ingress with several hosts and paths
+func (cr *WebSphereLibertyApplication) GetIngress() *WebSphereLibertyApplicationIngress {
+	return cr.Spec.Ingress
+}
+
+// GetGatewayRoute returns the settings of the Gateway API HTTPRoute
+func (cr *WebSphereLibertyApplication) GetGatewayRoute() *WebSphereLibertyApplicationGatewayRoute {
+	return cr.Spec.GatewayRoute
+}
+
+// GetLicense returns license and entitlement settings
+func (cr *WebSphereLibertyApplication) GetLicense() *WebSphereLibertyApplicationLicense {
+	return &cr.Spec.License
+}
+
 // GetPort returns service port
 func (s *WebSphereLibertyApplicationService) GetPort() int32 {
 	if s != nil && s.Port != 0 {
@@ -789,6 +1525,11 @@ func (m *WebSphereLibertyApplicationMonitoring) GetEndpoints() []prometheusv1.En
 	return m.Endpoints
 }
 
+// GetCredentialsSecretRef returns the name of the Secret with the credentials used to read the metrics
+func (m *WebSphereLibertyApplicationMonitoring) GetCredentialsSecretRef() *string {
+	return m.CredentialsSecretRef
+}
+
 // GetAnnotations returns route annotations
 func (r *WebSphereLibertyApplicationRoute) GetAnnotations() map[string]string {
 	return r.Annotations
@@ -844,13 +1585,14 @@ func (a *WebSphereLibertyApplicationAffinity) GetNodeAffinityLabels() map[string
 	return a.NodeAffinityLabels
 }
 
-// Initialize sets default values
-func (cr *WebSphereLibertyApplication) Initialize() {
-	if cr.Spec.PullPolicy == nil {
-		pp := corev1.PullIfNotPresent
-		cr.Spec.PullPolicy = &pp
-	}
+// GetTopologySpreadConstraints returns the constraints that control how pods are spread across topology domains
+func (a *WebSphereLibertyApplicationAffinity) GetTopologySpreadConstraints() []corev1.TopologySpreadConstraint {
+	return a.TopologySpreadConstraints
+}
 
+// Initialize sets default values. The defaults of the operator configuration are not set in the spec, but resolved by the
+// getters, so that changes to the operator configuration apply to existing applications.
+func (cr *WebSphereLibertyApplication) Initialize() {
 	if cr.Spec.ResourceConstraints == nil {
 		cr.Spec.ResourceConstraints = &corev1.ResourceRequirements{}
 	}
@@ -882,6 +1624,18 @@ func (cr *WebSphereLibertyApplication) Initialize() {
 		cr.Spec.Service.Port = 9080
 	}
 
+	if cr.Spec.License.Edition == "" {
+		cr.Spec.License.Edition = LicenseEditionBase
+	}
+
+	if cr.Spec.License.ProductEntitlementSource == "" {
+		cr.Spec.License.ProductEntitlementSource = LicenseEntitlementStandalone
+	}
+
+	if cr.Spec.License.Metric == "" {
+		cr.Spec.License.Metric = LicenseMetricVPC
+	}
+
 }
 
 // GetLabels returns set of labels to be added to all resources
@@ -1019,6 +1773,8 @@ func convertToCommonStatusConditionType(c StatusConditionType) common.StatusCond
 	switch c {
 	case StatusConditionTypeReconciled:
 		return common.StatusConditionTypeReconciled
+	case StatusConditionTypeResourcesReady, StatusConditionTypeReady, StatusConditionTypePaused, StatusConditionTypeLicenseAccepted:
+		return common.StatusConditionType(c)
 	default:
 		panic(c)
 	}
@@ -1028,6 +1784,9 @@ func convertFromCommonStatusConditionType(c common.StatusConditionType) StatusCo
 	switch c {
 	case common.StatusConditionTypeReconciled:
 		return StatusConditionTypeReconciled
+	case common.StatusConditionType(StatusConditionTypeResourcesReady), common.StatusConditionType(StatusConditionTypeReady),
+		common.StatusConditionType(StatusConditionTypePaused), common.StatusConditionType(StatusConditionTypeLicenseAccepted):
+		return StatusConditionType(c)
 	default:
 		panic(c)
 	}
@@ -0,0 +1,233 @@
+package v1
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+var webspherelibertyapplicationlog = logf.Log.WithName("webspherelibertyapplication-resource")
+
+// SetupWebhookWithManager registers the defaulting, validating and conversion webhooks of WebSphereLibertyApplication with
+// the manager
+func (cr *WebSphereLibertyApplication) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(cr).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-liberty-websphere-ibm-com-v1-webspherelibertyapplication,mutating=true,failurePolicy=fail,sideEffects=None,groups=liberty.websphere.ibm.com,resources=webspherelibertyapplications,verbs=create;update,versions=v1,name=mwebspherelibertyapplication.kb.io,admissionReviewVersions={v1,v1beta1}
+
+var _ webhook.Defaulter = &WebSphereLibertyApplication{}
+
+// Default sets the static default values of the WebSphereLibertyApplication when it is created or updated. The defaults of
+// the operator configuration are resolved by the getters at reconcile time instead, so that changes to it apply to existing
+// applications.
+func (cr *WebSphereLibertyApplication) Default() {
+	webspherelibertyapplicationlog.V(1).Info("default", "name", cr.Name)
+	cr.Initialize()
+}
+
+// +kubebuilder:webhook:path=/validate-liberty-websphere-ibm-com-v1-webspherelibertyapplication,mutating=false,failurePolicy=fail,sideEffects=None,groups=liberty.websphere.ibm.com,resources=webspherelibertyapplications,verbs=create;update,versions=v1,name=vwebspherelibertyapplication.kb.io,admissionReviewVersions={v1,v1beta1}
+
+var _ webhook.Validator = &WebSphereLibertyApplication{}
+
+// ValidateCreate rejects a WebSphereLibertyApplication that does not accept the license or has an invalid spec
+func (cr *WebSphereLibertyApplication) ValidateCreate() error {
+	webspherelibertyapplicationlog.V(1).Info("validate create", "name", cr.Name)
+	if err := cr.ValidateLicense(); err != nil {
+		return err
+	}
+	return cr.ValidateSpec()
+}
+
+// ValidateUpdate rejects an update that makes the spec of the WebSphereLibertyApplication invalid. The finalizer of an
+// application being deleted can always be removed. The license is only required once it has been accepted, so that the
+// applications created before it was introduced can still be updated.
+func (cr *WebSphereLibertyApplication) ValidateUpdate(old runtime.Object) error {
+	webspherelibertyapplicationlog.V(1).Info("validate update", "name", cr.Name)
+	if cr.DeletionTimestamp != nil {
+		return nil
+	}
+	if oldApp, ok := old.(*WebSphereLibertyApplication); ok && oldApp.GetLicense().Accept {
+		if err := cr.ValidateLicense(); err != nil {
+			return err
+		}
+	}
+	return cr.ValidateSpec()
+}
+
+// ValidateDelete allows the deletion of any WebSphereLibertyApplication
+func (cr *WebSphereLibertyApplication) ValidateDelete() error {
+	return nil
+}
+
+// ValidateLicense returns an error if the license is not accepted in the spec of the WebSphereLibertyApplication
+func (cr *WebSphereLibertyApplication) ValidateLicense() error {
+	if !cr.GetLicense().Accept {
+		return fmt.Errorf("License not accepted. Set spec.license.accept to true to confirm that you have read and accepted the license agreement at https://ibm.biz/was-license")
+	}
+	return nil
+}
+
+// ValidateSpec returns an error describing the first invalid input in the spec of the WebSphereLibertyApplication
+func (cr *WebSphereLibertyApplication) ValidateSpec() error {
+	// Storage validation
+	if cr.Spec.StatefulSet != nil && cr.Spec.StatefulSet.Storage != nil && cr.Spec.StatefulSet.Storage.GetVolumeClaimTemplate() == nil {
+		size := cr.Spec.StatefulSet.Storage.GetSize()
+		if size == "" {
+			return fmt.Errorf("validation failed: " + requiredFieldMessage("spec.statefulSet.storage.size"))
+		}
+		if _, err := resource.ParseQuantity(size); err != nil {
+			return fmt.Errorf("validation failed: cannot parse '%v': %v", size, err)
+		}
+	}
+
+	// Workload validation
+	if cr.Spec.StatefulSet != nil && cr.Spec.CreateKnativeService != nil && *cr.Spec.CreateKnativeService {
+		return fmt.Errorf("Invalid input for StatefulSet. Specify only one of the following: spec.statefulSet, spec.createKnativeService")
+	}
+
+	// Serviceability validation
+	if cr.GetServiceability() != nil {
+		if cr.GetServiceability().GetVolumeClaimName() == "" && cr.GetServiceability().GetSize() == "" {
+			return fmt.Errorf("Invalid input for Serviceability. Specify one of the following: spec.serviceability.size, spec.serviceability.volumeClaimName")
+		}
+		if cr.GetServiceability().GetVolumeClaimName() == "" {
+			if _, err := resource.ParseQuantity(cr.GetServiceability().GetSize()); err != nil {
+				return fmt.Errorf("validation failed: cannot parse '%v': %v", cr.GetServiceability().GetSize(), err)
+			}
+		}
+	}
+
+	// Server configuration validation
+	if cr.GetServerConfig() != nil {
+		var sources []WebSphereLibertyApplicationServerConfigSource
+		sources = append(sources, cr.GetServerConfig().GetOverrides()...)
+		sources = append(sources, cr.GetServerConfig().GetDefaults()...)
+		for _, src := range sources {
+			if (src.ConfigMapName == "") == (src.SecretName == "") {
+				return fmt.Errorf("Invalid input for ServerConfig. Specify exactly one of the following for each source: configMapName, secretName")
+			}
+		}
+	}
+
+	// InstantOn validation
+	if cr.GetInstantOn() != nil {
+		if cr.Spec.Affinity != nil {
+			for _, arch := range cr.Spec.Affinity.GetArchitecture() {
+				if arch != "amd64" {
+					return fmt.Errorf("Invalid input for InstantOn. Liberty InstantOn only supports the amd64 architecture in spec.affinity.architecture")
+				}
+			}
+		}
+		if sc := cr.GetSecurityContext(); sc != nil && ((sc.Privileged != nil && *sc.Privileged) || (sc.RunAsUser != nil && *sc.RunAsUser == 0) ||
+			(sc.RunAsNonRoot != nil && !*sc.RunAsNonRoot)) {
+			return fmt.Errorf("Invalid input for InstantOn. The application container must not run privileged or as the root user in spec.securityContext")
+		}
+		for _, sidecar := range cr.Spec.SidecarContainers {
+			if sc := sidecar.SecurityContext; sc != nil && ((sc.Privileged != nil && *sc.Privileged) || (sc.RunAsUser != nil && *sc.RunAsUser == 0)) {
+				return fmt.Errorf("Invalid input for InstantOn. Sidecar container %s must not run privileged or as the root user", sidecar.Name)
+			}
+		}
+		if cr.GetInstantOn().RestoreJavaOptions != "" {
+			for _, env := range cr.Spec.Env {
+				if env.Name == "OPENJ9_RESTORE_JAVA_OPTIONS" {
+					return fmt.Errorf("Invalid input for InstantOn. Specify only one of the following: spec.instantOn.restoreJavaOptions, OPENJ9_RESTORE_JAVA_OPTIONS in spec.env")
+				}
+			}
+		}
+	}
+
+	// Disruption budget validation
+	if db := cr.GetDisruptionBudget(); db != nil && db.MinAvailable != nil && db.MaxUnavailable != nil {
+		return fmt.Errorf("Invalid input for DisruptionBudget. Specify only one of the following: spec.disruptionBudget.minAvailable, spec.disruptionBudget.maxUnavailable")
+	}
+
+	// Transaction recovery validation
+	if cr.Spec.StatefulSet != nil && cr.Spec.StatefulSet.GetTransactionRecovery() != nil {
+		if cr.Spec.StatefulSet.GetStorage() == nil {
+			return fmt.Errorf("Invalid input for TransactionRecovery. spec.statefulSet.storage is required to persist the transaction logs")
+		}
+		if cr.GetAutoscaling() != nil {
+			return fmt.Errorf("Invalid input for TransactionRecovery. spec.autoscaling is not supported, because pods are only removed after their transactions are recovered")
+		}
+	}
+
+	// Session cache validation
+	if sc := cr.GetSessionCache(); sc != nil {
+		if sc.Provision && sc.Provider != SessionCacheProviderInfinispan {
+			return fmt.Errorf("Invalid input for SessionCache. spec.sessionCache.provision is only supported with the Infinispan provider")
+		}
+		if !sc.Provision && sc.ServerList == "" {
+			return fmt.Errorf("Invalid input for SessionCache. Specify one of the following: spec.sessionCache.serverList, spec.sessionCache.provision")
+		}
+		if sc.CredentialsSecretRef != nil && (sc.Provision || sc.Provider != SessionCacheProviderInfinispan) {
+			return fmt.Errorf("Invalid input for SessionCache. spec.sessionCache.credentialsSecretRef is only supported with an existing Infinispan cluster in spec.sessionCache.serverList")
+		}
+	}
+
+	// Autoscaling validation
+	if as := cr.Spec.Autoscaling; as != nil && as.TargetMemoryUtilizationPercentage != nil {
+		if rc := cr.GetResourceConstraints(); rc == nil || rc.Requests.Memory().IsZero() {
+			return fmt.Errorf("Invalid input for Autoscaling. spec.resourceConstraints.requests.memory is required to target memory utilization")
+		}
+	}
+
+	// Rollout validation
+	if rollout := cr.GetRollout(); rollout != nil {
+		if (rollout.Canary == nil) == (rollout.BlueGreen == nil) {
+			return fmt.Errorf("Invalid input for Rollout. Specify exactly one of the following: spec.rollout.canary, spec.rollout.blueGreen")
+		}
+		if cr.Spec.StatefulSet != nil || (cr.Spec.CreateKnativeService != nil && *cr.Spec.CreateKnativeService) {
+			return fmt.Errorf("Invalid input for Rollout. spec.rollout is only supported with a Deployment")
+		}
+	}
+
+	// Ingress validation
+	if ingress := cr.GetIngress(); ingress != nil {
+		for _, rule := range ingress.Rules {
+			for _, p := range rule.Paths {
+				if p.Port != 0 && !cr.hasServicePort(p.Port) {
+					return fmt.Errorf("Invalid input for Ingress. Port %d of host %s must be spec.service.port or the port of one of spec.service.ports", p.Port, rule.Host)
+				}
+			}
+		}
+	}
+
+	// Gateway route validation
+	if cr.GetGatewayRoute() != nil && cr.Spec.CreateKnativeService != nil && *cr.Spec.CreateKnativeService {
+		return fmt.Errorf("Invalid input for GatewayRoute. spec.gatewayRoute is not supported with spec.createKnativeService, because Knative exposes the application")
+	}
+
+	// Service mesh validation
+	if cr.GetServiceMesh() != nil && cr.Spec.CreateKnativeService != nil && *cr.Spec.CreateKnativeService {
+		return fmt.Errorf("Invalid input for ServiceMesh. spec.serviceMesh is not supported with spec.createKnativeService")
+	}
+
+	return nil
+}
+
+// hasServicePort returns true if the application Service exposes the port
+func (cr *WebSphereLibertyApplication) hasServicePort(port int32) bool {
+	if port == cr.Spec.Service.GetPort() {
+		return true
+	}
+	if cr.Spec.Service != nil {
+		for _, p := range cr.Spec.Service.Ports {
+			if p.Port == port {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func requiredFieldMessage(fieldPaths ...string) string {
+	return "must set the field(s): " + strings.Join(fieldPaths, ",")
+}
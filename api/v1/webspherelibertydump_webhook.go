@@ -0,0 +1,69 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+var webspherelibertydumplog = logf.Log.WithName("webspherelibertydump-resource")
+
+// webhookClient reads the pods targeted by the day-2 operations. It reads from the API server, because the manager
+// cache might not have started when the first admission requests are served.
+var webhookClient client.Reader
+
+// SetupWebhookWithManager registers the validating webhook of WebSphereLibertyDump with the manager
+func (cr *WebSphereLibertyDump) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	webhookClient = mgr.GetAPIReader()
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(cr).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-liberty-websphere-ibm-com-v1-webspherelibertydump,mutating=false,failurePolicy=fail,sideEffects=None,groups=liberty.websphere.ibm.com,resources=webspherelibertydumps,verbs=create;update,versions=v1,name=vwebspherelibertydump.kb.io,admissionReviewVersions={v1,v1beta1}
+
+var _ webhook.Validator = &WebSphereLibertyDump{}
+
+// ValidateCreate rejects a WebSphereLibertyDump of a pod that does not exist
+func (cr *WebSphereLibertyDump) ValidateCreate() error {
+	webspherelibertydumplog.V(1).Info("validate create", "name", cr.Name)
+	return validatePodExists(cr.Namespace, cr.Spec.PodName, "spec.podName")
+}
+
+// ValidateUpdate rejects a WebSphereLibertyDump that is changed to a pod that does not exist. The dumped pod itself
+// might be gone by the time the WebSphereLibertyDump is updated.
+func (cr *WebSphereLibertyDump) ValidateUpdate(old runtime.Object) error {
+	webspherelibertydumplog.V(1).Info("validate update", "name", cr.Name)
+	if oldDump, ok := old.(*WebSphereLibertyDump); ok && oldDump.Spec.PodName == cr.Spec.PodName {
+		return nil
+	}
+	return validatePodExists(cr.Namespace, cr.Spec.PodName, "spec.podName")
+}
+
+// ValidateDelete allows the deletion of any WebSphereLibertyDump
+func (cr *WebSphereLibertyDump) ValidateDelete() error {
+	return nil
+}
+
+// validatePodExists returns an error if the pod targeted by a day-2 operation is not found in its namespace
+func validatePodExists(namespace string, podName string, field string) error {
+	if webhookClient == nil {
+		return nil
+	}
+	err := webhookClient.Get(context.TODO(), types.NamespacedName{Name: podName, Namespace: namespace}, &corev1.Pod{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return fmt.Errorf("Invalid input for %s. Pod %s was not found in namespace %s", field, podName, namespace)
+		}
+		return fmt.Errorf("Failed to get pod %s in namespace %s: %v", podName, namespace, err)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package v1
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+var webspherelibertytracelog = logf.Log.WithName("webspherelibertytrace-resource")
+
+// SetupWebhookWithManager registers the defaulting and validating webhooks of WebSphereLibertyTrace with the manager
+func (cr *WebSphereLibertyTrace) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	webhookClient = mgr.GetAPIReader()
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(cr).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-liberty-websphere-ibm-com-v1-webspherelibertytrace,mutating=true,failurePolicy=fail,sideEffects=None,groups=liberty.websphere.ibm.com,resources=webspherelibertytraces,verbs=create;update,versions=v1,name=mwebspherelibertytrace.kb.io,admissionReviewVersions={v1,v1beta1}
+
+var _ webhook.Defaulter = &WebSphereLibertyTrace{}
+
+// Default sets the default values of the WebSphereLibertyTrace when it is created or updated
+func (cr *WebSphereLibertyTrace) Default() {
+	webspherelibertytracelog.V(1).Info("default", "name", cr.Name)
+	cr.Initialize()
+}
+
+// +kubebuilder:webhook:path=/validate-liberty-websphere-ibm-com-v1-webspherelibertytrace,mutating=false,failurePolicy=fail,sideEffects=None,groups=liberty.websphere.ibm.com,resources=webspherelibertytraces,verbs=create;update,versions=v1,name=vwebspherelibertytrace.kb.io,admissionReviewVersions={v1,v1beta1}
+
+var _ webhook.Validator = &WebSphereLibertyTrace{}
+
+// ValidateCreate rejects a WebSphereLibertyTrace with an invalid spec or of a pod that does not exist
+func (cr *WebSphereLibertyTrace) ValidateCreate() error {
+	webspherelibertytracelog.V(1).Info("validate create", "name", cr.Name)
+	if err := cr.ValidateSpec(); err != nil {
+		return err
+	}
+	return validatePodExists(cr.Namespace, cr.Spec.PodName, "spec.podName")
+}
+
+// ValidateUpdate rejects a WebSphereLibertyTrace with an invalid spec or that is moved to a pod that does not exist.
+// Tracing can always be disabled, and the finalizer removed, once the traced pod is gone.
+func (cr *WebSphereLibertyTrace) ValidateUpdate(old runtime.Object) error {
+	webspherelibertytracelog.V(1).Info("validate update", "name", cr.Name)
+	if cr.DeletionTimestamp != nil {
+		return nil
+	}
+	if err := cr.ValidateSpec(); err != nil {
+		return err
+	}
+	if oldTrace, ok := old.(*WebSphereLibertyTrace); ok && oldTrace.Spec.PodName == cr.Spec.PodName {
+		return nil
+	}
+	if cr.Spec.Disable != nil && *cr.Spec.Disable {
+		return nil
+	}
+	return validatePodExists(cr.Namespace, cr.Spec.PodName, "spec.podName")
+}
+
+// ValidateDelete allows the deletion of any WebSphereLibertyTrace
+func (cr *WebSphereLibertyTrace) ValidateDelete() error {
+	return nil
+}
+
+// ValidateSpec returns an error describing the first invalid input in the spec of the WebSphereLibertyTrace
+func (cr *WebSphereLibertyTrace) ValidateSpec() error {
+	if cr.Spec.MaxFileSize != nil && *cr.Spec.MaxFileSize < 0 {
+		return fmt.Errorf("Invalid input for MaxFileSize. spec.maxFileSize must not be negative")
+	}
+	if cr.Spec.MaxFiles != nil && *cr.Spec.MaxFiles < 0 {
+		return fmt.Errorf("Invalid input for MaxFiles. spec.maxFiles must not be negative")
+	}
+	return nil
+}
@@ -25,8 +25,12 @@ import (
 	monitoringv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
 	routev1 "github.com/openshift/api/route/v1"
 	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/api/autoscaling/v2beta2"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -129,6 +133,44 @@ func (in *OperationStatusCondition) DeepCopy() *OperationStatusCondition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutStatus) DeepCopyInto(out *RolloutStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.StepStartTime != nil {
+		in, out := &in.StepStartTime, &out.StepStartTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutStatus.
+func (in *RolloutStatus) DeepCopy() *RolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SemeruCompilerStatus) DeepCopyInto(out *SemeruCompilerStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SemeruCompilerStatus.
+func (in *SemeruCompilerStatus) DeepCopy() *SemeruCompilerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SemeruCompilerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StatusCondition) DeepCopyInto(out *StatusCondition) {
 	*out = *in
@@ -148,6 +190,21 @@ func (in *StatusCondition) DeepCopy() *StatusCondition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TransactionRecoveryStatus) DeepCopyInto(out *TransactionRecoveryStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransactionRecoveryStatus.
+func (in *TransactionRecoveryStatus) DeepCopy() *TransactionRecoveryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TransactionRecoveryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WebSphereLibertyApplication) DeepCopyInto(out *WebSphereLibertyApplication) {
 	*out = *in
@@ -205,6 +262,13 @@ func (in *WebSphereLibertyApplicationAffinity) DeepCopyInto(out *WebSphereLibert
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]corev1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebSphereLibertyApplicationAffinity.
@@ -230,6 +294,23 @@ func (in *WebSphereLibertyApplicationAutoScaling) DeepCopyInto(out *WebSphereLib
 		*out = new(int32)
 		**out = **in
 	}
+	if in.TargetMemoryUtilizationPercentage != nil {
+		in, out := &in.TargetMemoryUtilizationPercentage, &out.TargetMemoryUtilizationPercentage
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = make([]v2beta2.MetricSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Behavior != nil {
+		in, out := &in.Behavior, &out.Behavior
+		*out = new(v2beta2.HorizontalPodAutoscalerBehavior)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebSphereLibertyApplicationAutoScaling.
@@ -242,6 +323,108 @@ func (in *WebSphereLibertyApplicationAutoScaling) DeepCopy() *WebSphereLibertyAp
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebSphereLibertyApplicationBlueGreen) DeepCopyInto(out *WebSphereLibertyApplicationBlueGreen) {
+	*out = *in
+	if in.PromotionDelay != nil {
+		in, out := &in.PromotionDelay, &out.PromotionDelay
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebSphereLibertyApplicationBlueGreen.
+func (in *WebSphereLibertyApplicationBlueGreen) DeepCopy() *WebSphereLibertyApplicationBlueGreen {
+	if in == nil {
+		return nil
+	}
+	out := new(WebSphereLibertyApplicationBlueGreen)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebSphereLibertyApplicationCanary) DeepCopyInto(out *WebSphereLibertyApplicationCanary) {
+	*out = *in
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]WebSphereLibertyApplicationCanaryStep, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebSphereLibertyApplicationCanary.
+func (in *WebSphereLibertyApplicationCanary) DeepCopy() *WebSphereLibertyApplicationCanary {
+	if in == nil {
+		return nil
+	}
+	out := new(WebSphereLibertyApplicationCanary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebSphereLibertyApplicationCanaryStep) DeepCopyInto(out *WebSphereLibertyApplicationCanaryStep) {
+	*out = *in
+	if in.Pause != nil {
+		in, out := &in.Pause, &out.Pause
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebSphereLibertyApplicationCanaryStep.
+func (in *WebSphereLibertyApplicationCanaryStep) DeepCopy() *WebSphereLibertyApplicationCanaryStep {
+	if in == nil {
+		return nil
+	}
+	out := new(WebSphereLibertyApplicationCanaryStep)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebSphereLibertyApplicationConnectionPool) DeepCopyInto(out *WebSphereLibertyApplicationConnectionPool) {
+	*out = *in
+	if in.MaxConnections != nil {
+		in, out := &in.MaxConnections, &out.MaxConnections
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ConnectTimeout != nil {
+		in, out := &in.ConnectTimeout, &out.ConnectTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MaxPendingRequests != nil {
+		in, out := &in.MaxPendingRequests, &out.MaxPendingRequests
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxRequests != nil {
+		in, out := &in.MaxRequests, &out.MaxRequests
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxRequestsPerConnection != nil {
+		in, out := &in.MaxRequestsPerConnection, &out.MaxRequestsPerConnection
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebSphereLibertyApplicationConnectionPool.
+func (in *WebSphereLibertyApplicationConnectionPool) DeepCopy() *WebSphereLibertyApplicationConnectionPool {
+	if in == nil {
+		return nil
+	}
+	out := new(WebSphereLibertyApplicationConnectionPool)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WebSphereLibertyApplicationDeployment) DeepCopyInto(out *WebSphereLibertyApplicationDeployment) {
 	*out = *in
@@ -269,6 +452,222 @@ func (in *WebSphereLibertyApplicationDeployment) DeepCopy() *WebSphereLibertyApp
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebSphereLibertyApplicationDisruptionBudget) DeepCopyInto(out *WebSphereLibertyApplicationDisruptionBudget) {
+	*out = *in
+	if in.MinAvailable != nil {
+		in, out := &in.MinAvailable, &out.MinAvailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebSphereLibertyApplicationDisruptionBudget.
+func (in *WebSphereLibertyApplicationDisruptionBudget) DeepCopy() *WebSphereLibertyApplicationDisruptionBudget {
+	if in == nil {
+		return nil
+	}
+	out := new(WebSphereLibertyApplicationDisruptionBudget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebSphereLibertyApplicationGatewayHeaderMatch) DeepCopyInto(out *WebSphereLibertyApplicationGatewayHeaderMatch) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebSphereLibertyApplicationGatewayHeaderMatch.
+func (in *WebSphereLibertyApplicationGatewayHeaderMatch) DeepCopy() *WebSphereLibertyApplicationGatewayHeaderMatch {
+	if in == nil {
+		return nil
+	}
+	out := new(WebSphereLibertyApplicationGatewayHeaderMatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebSphereLibertyApplicationGatewayReference) DeepCopyInto(out *WebSphereLibertyApplicationGatewayReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebSphereLibertyApplicationGatewayReference.
+func (in *WebSphereLibertyApplicationGatewayReference) DeepCopy() *WebSphereLibertyApplicationGatewayReference {
+	if in == nil {
+		return nil
+	}
+	out := new(WebSphereLibertyApplicationGatewayReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebSphereLibertyApplicationGatewayRoute) DeepCopyInto(out *WebSphereLibertyApplicationGatewayRoute) {
+	*out = *in
+	out.Gateway = in.Gateway
+	if in.Hostnames != nil {
+		in, out := &in.Hostnames, &out.Hostnames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Matches != nil {
+		in, out := &in.Matches, &out.Matches
+		*out = make([]WebSphereLibertyApplicationGatewayRouteMatch, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebSphereLibertyApplicationGatewayRoute.
+func (in *WebSphereLibertyApplicationGatewayRoute) DeepCopy() *WebSphereLibertyApplicationGatewayRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(WebSphereLibertyApplicationGatewayRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebSphereLibertyApplicationGatewayRouteMatch) DeepCopyInto(out *WebSphereLibertyApplicationGatewayRouteMatch) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]WebSphereLibertyApplicationGatewayHeaderMatch, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebSphereLibertyApplicationGatewayRouteMatch.
+func (in *WebSphereLibertyApplicationGatewayRouteMatch) DeepCopy() *WebSphereLibertyApplicationGatewayRouteMatch {
+	if in == nil {
+		return nil
+	}
+	out := new(WebSphereLibertyApplicationGatewayRouteMatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebSphereLibertyApplicationIngress) DeepCopyInto(out *WebSphereLibertyApplicationIngress) {
+	*out = *in
+	if in.IngressClassName != nil {
+		in, out := &in.IngressClassName, &out.IngressClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]WebSphereLibertyApplicationIngressRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebSphereLibertyApplicationIngress.
+func (in *WebSphereLibertyApplicationIngress) DeepCopy() *WebSphereLibertyApplicationIngress {
+	if in == nil {
+		return nil
+	}
+	out := new(WebSphereLibertyApplicationIngress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebSphereLibertyApplicationIngressPath) DeepCopyInto(out *WebSphereLibertyApplicationIngressPath) {
+	*out = *in
+	if in.PathType != nil {
+		in, out := &in.PathType, &out.PathType
+		*out = new(networkingv1.PathType)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebSphereLibertyApplicationIngressPath.
+func (in *WebSphereLibertyApplicationIngressPath) DeepCopy() *WebSphereLibertyApplicationIngressPath {
+	if in == nil {
+		return nil
+	}
+	out := new(WebSphereLibertyApplicationIngressPath)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebSphereLibertyApplicationIngressRule) DeepCopyInto(out *WebSphereLibertyApplicationIngressRule) {
+	*out = *in
+	if in.Paths != nil {
+		in, out := &in.Paths, &out.Paths
+		*out = make([]WebSphereLibertyApplicationIngressPath, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebSphereLibertyApplicationIngressRule.
+func (in *WebSphereLibertyApplicationIngressRule) DeepCopy() *WebSphereLibertyApplicationIngressRule {
+	if in == nil {
+		return nil
+	}
+	out := new(WebSphereLibertyApplicationIngressRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebSphereLibertyApplicationInstantOn) DeepCopyInto(out *WebSphereLibertyApplicationInstantOn) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebSphereLibertyApplicationInstantOn.
+func (in *WebSphereLibertyApplicationInstantOn) DeepCopy() *WebSphereLibertyApplicationInstantOn {
+	if in == nil {
+		return nil
+	}
+	out := new(WebSphereLibertyApplicationInstantOn)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebSphereLibertyApplicationLicense) DeepCopyInto(out *WebSphereLibertyApplicationLicense) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebSphereLibertyApplicationLicense.
+func (in *WebSphereLibertyApplicationLicense) DeepCopy() *WebSphereLibertyApplicationLicense {
+	if in == nil {
+		return nil
+	}
+	out := new(WebSphereLibertyApplicationLicense)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WebSphereLibertyApplicationList) DeepCopyInto(out *WebSphereLibertyApplicationList) {
 	*out = *in
@@ -318,6 +717,16 @@ func (in *WebSphereLibertyApplicationMonitoring) DeepCopyInto(out *WebSphereLibe
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(string)
+		**out = **in
+	}
+	if in.GenerateCredentials != nil {
+		in, out := &in.GenerateCredentials, &out.GenerateCredentials
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebSphereLibertyApplicationMonitoring.
@@ -330,6 +739,72 @@ func (in *WebSphereLibertyApplicationMonitoring) DeepCopy() *WebSphereLibertyApp
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebSphereLibertyApplicationNetworkPolicy) DeepCopyInto(out *WebSphereLibertyApplicationNetworkPolicy) {
+	*out = *in
+	if in.NamespaceLabels != nil {
+		in, out := &in.NamespaceLabels, &out.NamespaceLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.FromLabels != nil {
+		in, out := &in.FromLabels, &out.FromLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.From != nil {
+		in, out := &in.From, &out.From
+		*out = make([]networkingv1.NetworkPolicyPeer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebSphereLibertyApplicationNetworkPolicy.
+func (in *WebSphereLibertyApplicationNetworkPolicy) DeepCopy() *WebSphereLibertyApplicationNetworkPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(WebSphereLibertyApplicationNetworkPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebSphereLibertyApplicationRollout) DeepCopyInto(out *WebSphereLibertyApplicationRollout) {
+	*out = *in
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(WebSphereLibertyApplicationCanary)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BlueGreen != nil {
+		in, out := &in.BlueGreen, &out.BlueGreen
+		*out = new(WebSphereLibertyApplicationBlueGreen)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ProgressDeadlineSeconds != nil {
+		in, out := &in.ProgressDeadlineSeconds, &out.ProgressDeadlineSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebSphereLibertyApplicationRollout.
+func (in *WebSphereLibertyApplicationRollout) DeepCopy() *WebSphereLibertyApplicationRollout {
+	if in == nil {
+		return nil
+	}
+	out := new(WebSphereLibertyApplicationRollout)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WebSphereLibertyApplicationRoute) DeepCopyInto(out *WebSphereLibertyApplicationRoute) {
 	*out = *in
@@ -406,6 +881,71 @@ func (in *WebSphereLibertyApplicationSSO) DeepCopy() *WebSphereLibertyApplicatio
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebSphereLibertyApplicationSemeruCloudCompiler) DeepCopyInto(out *WebSphereLibertyApplicationSemeruCloudCompiler) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebSphereLibertyApplicationSemeruCloudCompiler.
+func (in *WebSphereLibertyApplicationSemeruCloudCompiler) DeepCopy() *WebSphereLibertyApplicationSemeruCloudCompiler {
+	if in == nil {
+		return nil
+	}
+	out := new(WebSphereLibertyApplicationSemeruCloudCompiler)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebSphereLibertyApplicationServerConfig) DeepCopyInto(out *WebSphereLibertyApplicationServerConfig) {
+	*out = *in
+	if in.Overrides != nil {
+		in, out := &in.Overrides, &out.Overrides
+		*out = make([]WebSphereLibertyApplicationServerConfigSource, len(*in))
+		copy(*out, *in)
+	}
+	if in.Defaults != nil {
+		in, out := &in.Defaults, &out.Defaults
+		*out = make([]WebSphereLibertyApplicationServerConfigSource, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebSphereLibertyApplicationServerConfig.
+func (in *WebSphereLibertyApplicationServerConfig) DeepCopy() *WebSphereLibertyApplicationServerConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(WebSphereLibertyApplicationServerConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebSphereLibertyApplicationServerConfigSource) DeepCopyInto(out *WebSphereLibertyApplicationServerConfigSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebSphereLibertyApplicationServerConfigSource.
+func (in *WebSphereLibertyApplicationServerConfigSource) DeepCopy() *WebSphereLibertyApplicationServerConfigSource {
+	if in == nil {
+		return nil
+	}
+	out := new(WebSphereLibertyApplicationServerConfigSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WebSphereLibertyApplicationService) DeepCopyInto(out *WebSphereLibertyApplicationService) {
 	*out = *in
@@ -460,6 +1000,36 @@ func (in *WebSphereLibertyApplicationService) DeepCopy() *WebSphereLibertyApplic
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebSphereLibertyApplicationServiceMesh) DeepCopyInto(out *WebSphereLibertyApplicationServiceMesh) {
+	*out = *in
+	if in.Hosts != nil {
+		in, out := &in.Hosts, &out.Hosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Gateways != nil {
+		in, out := &in.Gateways, &out.Gateways
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ConnectionPool != nil {
+		in, out := &in.ConnectionPool, &out.ConnectionPool
+		*out = new(WebSphereLibertyApplicationConnectionPool)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebSphereLibertyApplicationServiceMesh.
+func (in *WebSphereLibertyApplicationServiceMesh) DeepCopy() *WebSphereLibertyApplicationServiceMesh {
+	if in == nil {
+		return nil
+	}
+	out := new(WebSphereLibertyApplicationServiceMesh)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WebSphereLibertyApplicationServiceability) DeepCopyInto(out *WebSphereLibertyApplicationServiceability) {
 	*out = *in
@@ -475,6 +1045,31 @@ func (in *WebSphereLibertyApplicationServiceability) DeepCopy() *WebSphereLibert
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebSphereLibertyApplicationSessionCache) DeepCopyInto(out *WebSphereLibertyApplicationSessionCache) {
+	*out = *in
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(string)
+		**out = **in
+	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebSphereLibertyApplicationSessionCache.
+func (in *WebSphereLibertyApplicationSessionCache) DeepCopy() *WebSphereLibertyApplicationSessionCache {
+	if in == nil {
+		return nil
+	}
+	out := new(WebSphereLibertyApplicationSessionCache)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WebSphereLibertyApplicationSpec) DeepCopyInto(out *WebSphereLibertyApplicationSpec) {
 	*out = *in
@@ -538,11 +1133,21 @@ func (in *WebSphereLibertyApplicationSpec) DeepCopyInto(out *WebSphereLibertyApp
 		*out = new(WebSphereLibertyApplicationRoute)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = new(WebSphereLibertyApplicationIngress)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.CreateKnativeService != nil {
 		in, out := &in.CreateKnativeService, &out.CreateKnativeService
 		*out = new(bool)
 		**out = **in
 	}
+	if in.DefaultHealthProbes != nil {
+		in, out := &in.DefaultHealthProbes, &out.DefaultHealthProbes
+		*out = new(bool)
+		**out = **in
+	}
 	if in.LivenessProbe != nil {
 		in, out := &in.LivenessProbe, &out.LivenessProbe
 		*out = new(corev1.Probe)
@@ -615,6 +1220,57 @@ func (in *WebSphereLibertyApplicationSpec) DeepCopyInto(out *WebSphereLibertyApp
 		*out = new(WebSphereLibertyApplicationSSO)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ServerConfig != nil {
+		in, out := &in.ServerConfig, &out.ServerConfig
+		*out = new(WebSphereLibertyApplicationServerConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	out.License = in.License
+	if in.NetworkPolicy != nil {
+		in, out := &in.NetworkPolicy, &out.NetworkPolicy
+		*out = new(WebSphereLibertyApplicationNetworkPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DisruptionBudget != nil {
+		in, out := &in.DisruptionBudget, &out.DisruptionBudget
+		*out = new(WebSphereLibertyApplicationDisruptionBudget)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SemeruCloudCompiler != nil {
+		in, out := &in.SemeruCloudCompiler, &out.SemeruCloudCompiler
+		*out = new(WebSphereLibertyApplicationSemeruCloudCompiler)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InstantOn != nil {
+		in, out := &in.InstantOn, &out.InstantOn
+		*out = new(WebSphereLibertyApplicationInstantOn)
+		**out = **in
+	}
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(corev1.SecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SessionCache != nil {
+		in, out := &in.SessionCache, &out.SessionCache
+		*out = new(WebSphereLibertyApplicationSessionCache)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(WebSphereLibertyApplicationRollout)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GatewayRoute != nil {
+		in, out := &in.GatewayRoute, &out.GatewayRoute
+		*out = new(WebSphereLibertyApplicationGatewayRoute)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceMesh != nil {
+		in, out := &in.ServiceMesh, &out.ServiceMesh
+		*out = new(WebSphereLibertyApplicationServiceMesh)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebSphereLibertyApplicationSpec.
@@ -647,6 +1303,11 @@ func (in *WebSphereLibertyApplicationStatefulSet) DeepCopyInto(out *WebSphereLib
 			(*out)[key] = val
 		}
 	}
+	if in.TransactionRecovery != nil {
+		in, out := &in.TransactionRecovery, &out.TransactionRecovery
+		*out = new(WebSphereLibertyApplicationTransactionRecovery)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebSphereLibertyApplicationStatefulSet.
@@ -679,6 +1340,21 @@ func (in *WebSphereLibertyApplicationStatus) DeepCopyInto(out *WebSphereLibertyA
 		*out = new(corev1.LocalObjectReference)
 		**out = **in
 	}
+	if in.SemeruCompiler != nil {
+		in, out := &in.SemeruCompiler, &out.SemeruCompiler
+		*out = new(SemeruCompilerStatus)
+		**out = **in
+	}
+	if in.TransactionRecovery != nil {
+		in, out := &in.TransactionRecovery, &out.TransactionRecovery
+		*out = new(TransactionRecoveryStatus)
+		**out = **in
+	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(RolloutStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebSphereLibertyApplicationStatus.
@@ -711,6 +1387,21 @@ func (in *WebSphereLibertyApplicationStorage) DeepCopy() *WebSphereLibertyApplic
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebSphereLibertyApplicationTransactionRecovery) DeepCopyInto(out *WebSphereLibertyApplicationTransactionRecovery) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebSphereLibertyApplicationTransactionRecovery.
+func (in *WebSphereLibertyApplicationTransactionRecovery) DeepCopy() *WebSphereLibertyApplicationTransactionRecovery {
+	if in == nil {
+		return nil
+	}
+	out := new(WebSphereLibertyApplicationTransactionRecovery)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WebSphereLibertyDump) DeepCopyInto(out *WebSphereLibertyDump) {
 	*out = *in
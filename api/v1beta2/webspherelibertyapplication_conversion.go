@@ -0,0 +1,149 @@
+package v1beta2
+
+import (
+	webspherelibertyv1 "github.com/WASdev/websphere-liberty-operator/api/v1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+// ConvertTo converts this WebSphereLibertyApplication to the hub version (v1)
+func (src *WebSphereLibertyApplication) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*webspherelibertyv1.WebSphereLibertyApplication)
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Status = src.Status
+
+	dst.Spec.ApplicationName = src.Spec.ApplicationName
+	dst.Spec.ApplicationImage = src.Spec.ApplicationImage
+	dst.Spec.ApplicationVersion = src.Spec.ApplicationVersion
+	dst.Spec.Replicas = src.Spec.Replicas
+	dst.Spec.Expose = src.Spec.Expose
+	dst.Spec.ResourceConstraints = src.Spec.ResourceConstraints
+	dst.Spec.Service = src.Spec.Service
+	dst.Spec.Autoscaling = src.Spec.Autoscaling
+	dst.Spec.Deployment = src.Spec.Deployment
+	dst.Spec.StatefulSet = src.Spec.StatefulSet
+	dst.Spec.ServiceAccountName = src.Spec.ServiceAccountName
+	dst.Spec.Monitoring = src.Spec.Monitoring
+	dst.Spec.Affinity = src.Spec.Affinity
+	dst.Spec.Route = src.Spec.Route
+	dst.Spec.Ingress = src.Spec.Ingress
+	dst.Spec.CreateKnativeService = src.Spec.CreateKnativeService
+	dst.Spec.DefaultHealthProbes = src.Spec.DefaultHealthProbes
+	dst.Spec.Volumes = src.Spec.Volumes
+	dst.Spec.VolumeMounts = src.Spec.VolumeMounts
+	dst.Spec.Env = src.Spec.Env
+	dst.Spec.EnvFrom = src.Spec.EnvFrom
+	dst.Spec.InitContainers = src.Spec.InitContainers
+	dst.Spec.SidecarContainers = src.Spec.SidecarContainers
+	dst.Spec.Serviceability = src.Spec.Serviceability
+	dst.Spec.ServerConfig = src.Spec.ServerConfig
+	dst.Spec.License = src.Spec.License
+	dst.Spec.NetworkPolicy = src.Spec.NetworkPolicy
+	dst.Spec.DisruptionBudget = src.Spec.DisruptionBudget
+	dst.Spec.SemeruCloudCompiler = src.Spec.SemeruCloudCompiler
+	dst.Spec.InstantOn = src.Spec.InstantOn
+	dst.Spec.SecurityContext = src.Spec.SecurityContext
+	dst.Spec.SessionCache = src.Spec.SessionCache
+	dst.Spec.Rollout = src.Spec.Rollout
+	dst.Spec.GatewayRoute = src.Spec.GatewayRoute
+	dst.Spec.ServiceMesh = src.Spec.ServiceMesh
+
+	if image := src.Spec.Image; image != nil {
+		dst.Spec.PullPolicy = image.PullPolicy
+		dst.Spec.PullSecret = image.PullSecret
+	}
+
+	if probes := src.Spec.Probes; probes != nil {
+		dst.Spec.LivenessProbe = probes.Liveness
+		dst.Spec.ReadinessProbe = probes.Readiness
+		dst.Spec.StartupProbe = probes.Startup
+	}
+
+	if sso := src.Spec.SSO; sso != nil {
+		dst.Spec.SSO = &webspherelibertyv1.WebSphereLibertyApplicationSSO{
+			RedirectToRPHostAndPort: sso.RedirectToRPHostAndPort,
+			MapToUserRegistry:       sso.MapToUserRegistry,
+		}
+		if providers := sso.Providers; providers != nil {
+			dst.Spec.SSO.OIDC = providers.OIDC
+			dst.Spec.SSO.Oauth2 = providers.Oauth2
+			dst.Spec.SSO.Github = providers.Github
+		}
+	}
+
+	return nil
+}
+
+// ConvertFrom converts the hub version (v1) to this WebSphereLibertyApplication
+func (dst *WebSphereLibertyApplication) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*webspherelibertyv1.WebSphereLibertyApplication)
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Status = src.Status
+
+	dst.Spec.ApplicationName = src.Spec.ApplicationName
+	dst.Spec.ApplicationImage = src.Spec.ApplicationImage
+	dst.Spec.ApplicationVersion = src.Spec.ApplicationVersion
+	dst.Spec.Replicas = src.Spec.Replicas
+	dst.Spec.Expose = src.Spec.Expose
+	dst.Spec.ResourceConstraints = src.Spec.ResourceConstraints
+	dst.Spec.Service = src.Spec.Service
+	dst.Spec.Autoscaling = src.Spec.Autoscaling
+	dst.Spec.Deployment = src.Spec.Deployment
+	dst.Spec.StatefulSet = src.Spec.StatefulSet
+	dst.Spec.ServiceAccountName = src.Spec.ServiceAccountName
+	dst.Spec.Monitoring = src.Spec.Monitoring
+	dst.Spec.Affinity = src.Spec.Affinity
+	dst.Spec.Route = src.Spec.Route
+	dst.Spec.Ingress = src.Spec.Ingress
+	dst.Spec.CreateKnativeService = src.Spec.CreateKnativeService
+	dst.Spec.DefaultHealthProbes = src.Spec.DefaultHealthProbes
+	dst.Spec.Volumes = src.Spec.Volumes
+	dst.Spec.VolumeMounts = src.Spec.VolumeMounts
+	dst.Spec.Env = src.Spec.Env
+	dst.Spec.EnvFrom = src.Spec.EnvFrom
+	dst.Spec.InitContainers = src.Spec.InitContainers
+	dst.Spec.SidecarContainers = src.Spec.SidecarContainers
+	dst.Spec.Serviceability = src.Spec.Serviceability
+	dst.Spec.ServerConfig = src.Spec.ServerConfig
+	dst.Spec.License = src.Spec.License
+	dst.Spec.NetworkPolicy = src.Spec.NetworkPolicy
+	dst.Spec.DisruptionBudget = src.Spec.DisruptionBudget
+	dst.Spec.SemeruCloudCompiler = src.Spec.SemeruCloudCompiler
+	dst.Spec.InstantOn = src.Spec.InstantOn
+	dst.Spec.SecurityContext = src.Spec.SecurityContext
+	dst.Spec.SessionCache = src.Spec.SessionCache
+	dst.Spec.Rollout = src.Spec.Rollout
+	dst.Spec.GatewayRoute = src.Spec.GatewayRoute
+	dst.Spec.ServiceMesh = src.Spec.ServiceMesh
+
+	// The groups are only set when one of their fields is, so that converting back to v1 gives the same spec
+	if src.Spec.PullPolicy != nil || src.Spec.PullSecret != nil {
+		dst.Spec.Image = &WebSphereLibertyApplicationImage{
+			PullPolicy: src.Spec.PullPolicy,
+			PullSecret: src.Spec.PullSecret,
+		}
+	}
+
+	if src.Spec.LivenessProbe != nil || src.Spec.ReadinessProbe != nil || src.Spec.StartupProbe != nil {
+		dst.Spec.Probes = &WebSphereLibertyApplicationProbes{
+			Liveness:  src.Spec.LivenessProbe,
+			Readiness: src.Spec.ReadinessProbe,
+			Startup:   src.Spec.StartupProbe,
+		}
+	}
+
+	if sso := src.Spec.SSO; sso != nil {
+		dst.Spec.SSO = &WebSphereLibertyApplicationSSO{
+			RedirectToRPHostAndPort: sso.RedirectToRPHostAndPort,
+			MapToUserRegistry:       sso.MapToUserRegistry,
+		}
+		if sso.OIDC != nil || sso.Oauth2 != nil || sso.Github != nil {
+			dst.Spec.SSO.Providers = &WebSphereLibertyApplicationSSOProviders{
+				OIDC:   sso.OIDC,
+				Oauth2: sso.Oauth2,
+				Github: sso.Github,
+			}
+		}
+	}
+
+	return nil
+}
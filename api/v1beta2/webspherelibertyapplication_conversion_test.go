@@ -0,0 +1,77 @@
+package v1beta2
+
+import (
+	"reflect"
+	"testing"
+
+	webspherelibertyv1 "github.com/WASdev/websphere-liberty-operator/api/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestConvertRoundTrip(t *testing.T) {
+	pullPolicy := corev1.PullAlways
+	pullSecret := "pull-secret"
+	replicas := int32(2)
+	mapToUserRegistry := true
+	probe := &corev1.Probe{InitialDelaySeconds: 10}
+
+	hub := &webspherelibertyv1.WebSphereLibertyApplication{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "websphereliberty", Generation: 3},
+		Spec: webspherelibertyv1.WebSphereLibertyApplicationSpec{
+			ApplicationImage: "my-image",
+			PullPolicy:       &pullPolicy,
+			PullSecret:       &pullSecret,
+			Replicas:         &replicas,
+			LivenessProbe:    probe,
+			StartupProbe:     probe,
+			Env:              []corev1.EnvVar{{Name: "ENV", Value: "value"}},
+			SSO: &webspherelibertyv1.WebSphereLibertyApplicationSSO{
+				OIDC:              []webspherelibertyv1.OidcClient{{ID: "oidc"}},
+				Github:            &webspherelibertyv1.GithubLogin{Hostname: "github.example.com"},
+				MapToUserRegistry: &mapToUserRegistry,
+			},
+			License: webspherelibertyv1.WebSphereLibertyApplicationLicense{Accept: true},
+		},
+		Status: webspherelibertyv1.WebSphereLibertyApplicationStatus{Endpoint: "http://app.example.com"},
+	}
+
+	spoke := &WebSphereLibertyApplication{}
+	if err := spoke.ConvertFrom(hub); err != nil {
+		t.Fatal(err)
+	}
+	if spoke.Spec.Image == nil || spoke.Spec.Image.PullPolicy != &pullPolicy || spoke.Spec.Image.PullSecret != &pullSecret {
+		t.Errorf("spec.image: expected the pull policy and secret, got %+v", spoke.Spec.Image)
+	}
+	if spoke.Spec.Probes == nil || spoke.Spec.Probes.Liveness != probe || spoke.Spec.Probes.Readiness != nil || spoke.Spec.Probes.Startup != probe {
+		t.Errorf("spec.probes: expected the liveness and startup probes, got %+v", spoke.Spec.Probes)
+	}
+	if spoke.Spec.SSO.Providers == nil || len(spoke.Spec.SSO.Providers.OIDC) != 1 || spoke.Spec.SSO.Providers.Github == nil {
+		t.Errorf("spec.sso.providers: expected the OIDC and GitHub providers, got %+v", spoke.Spec.SSO.Providers)
+	}
+
+	converted := &webspherelibertyv1.WebSphereLibertyApplication{}
+	if err := spoke.ConvertTo(converted); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(hub, converted) {
+		t.Errorf("v1 to v1beta2 to v1: expected %+v, got %+v", hub, converted)
+	}
+
+	roundTripped := &WebSphereLibertyApplication{}
+	if err := roundTripped.ConvertFrom(converted); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(spoke, roundTripped) {
+		t.Errorf("v1beta2 to v1 to v1beta2: expected %+v, got %+v", spoke, roundTripped)
+	}
+
+	// Groups without any field set are not created
+	empty := &WebSphereLibertyApplication{}
+	if err := empty.ConvertFrom(&webspherelibertyv1.WebSphereLibertyApplication{}); err != nil {
+		t.Fatal(err)
+	}
+	if empty.Spec.Image != nil || empty.Spec.Probes != nil || empty.Spec.SSO != nil {
+		t.Errorf("empty spec: expected no groups, got %+v", empty.Spec)
+	}
+}
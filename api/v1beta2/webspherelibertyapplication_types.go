@@ -0,0 +1,194 @@
+package v1beta2
+
+import (
+	webspherelibertyv1 "github.com/WASdev/websphere-liberty-operator/api/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Defines the desired state of WebSphereLibertyApplication. The settings that are spread across the v1 spec are
+// grouped under spec.image, spec.probes and spec.sso.providers.
+type WebSphereLibertyApplicationSpec struct {
+	// The name of the application this resource is part of. If not specified, it defaults to the name of the CR.
+	ApplicationName string `json:"applicationName,omitempty"`
+
+	// Application image to be installed.
+	ApplicationImage string `json:"applicationImage"`
+
+	// Version of the application.
+	ApplicationVersion string `json:"applicationVersion,omitempty"`
+
+	Image *WebSphereLibertyApplicationImage `json:"image,omitempty"`
+
+	// Number of pods to create.
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// A boolean that toggles the external exposure of this deployment via a Route or a Knative Route resource.
+	Expose *bool `json:"expose,omitempty"`
+
+	// Limits the amount of required resources.
+	ResourceConstraints *corev1.ResourceRequirements `json:"resourceConstraints,omitempty"`
+
+	Service *webspherelibertyv1.WebSphereLibertyApplicationService `json:"service,omitempty"`
+
+	Autoscaling *webspherelibertyv1.WebSphereLibertyApplicationAutoScaling `json:"autoscaling,omitempty"`
+
+	Deployment *webspherelibertyv1.WebSphereLibertyApplicationDeployment `json:"deployment,omitempty"`
+
+	StatefulSet *webspherelibertyv1.WebSphereLibertyApplicationStatefulSet `json:"statefulSet,omitempty"`
+
+	// The name of the OpenShift service account to be used during deployment.
+	ServiceAccountName *string `json:"serviceAccountName,omitempty"`
+
+	Monitoring *webspherelibertyv1.WebSphereLibertyApplicationMonitoring `json:"monitoring,omitempty"`
+
+	Affinity *webspherelibertyv1.WebSphereLibertyApplicationAffinity `json:"affinity,omitempty"`
+
+	Route *webspherelibertyv1.WebSphereLibertyApplicationRoute `json:"route,omitempty"`
+
+	Ingress *webspherelibertyv1.WebSphereLibertyApplicationIngress `json:"ingress,omitempty"`
+
+	// A boolean to toggle the creation of Knative resources and usage of Knative serving.
+	CreateKnativeService *bool `json:"createKnativeService,omitempty"`
+
+	DefaultHealthProbes *bool `json:"defaultHealthProbes,omitempty"`
+
+	Probes *WebSphereLibertyApplicationProbes `json:"probes,omitempty"`
+
+	// Represents a pod volume with data that is accessible to the containers.
+	// +listType=map
+	// +listMapKey=name
+	Volumes []corev1.Volume `json:"volumes,omitempty"`
+
+	// Represents where to mount the volumes into containers.
+	// +listType=atomic
+	VolumeMounts []corev1.VolumeMount `json:"volumeMounts,omitempty"`
+
+	// An array of environment variables following the format of {name, value}, where value is a simple string.
+	// +listType=map
+	// +listMapKey=name
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// An array of references to ConfigMap or Secret resources containing environment variables.
+	// +listType=atomic
+	EnvFrom []corev1.EnvFromSource `json:"envFrom,omitempty"`
+
+	// List of containers that run before other containers in a pod.
+	// +listType=map
+	// +listMapKey=name
+	InitContainers []corev1.Container `json:"initContainers,omitempty"`
+
+	// The list of sidecar containers. These are additional containers to be added to the pods.
+	// +listType=map
+	// +listMapKey=name
+	SidecarContainers []corev1.Container `json:"sidecarContainers,omitempty"`
+
+	// WebSphere Liberty specific capabilities
+
+	Serviceability *webspherelibertyv1.WebSphereLibertyApplicationServiceability `json:"serviceability,omitempty"`
+
+	SSO *WebSphereLibertyApplicationSSO `json:"sso,omitempty"`
+
+	ServerConfig *webspherelibertyv1.WebSphereLibertyApplicationServerConfig `json:"serverConfig,omitempty"`
+
+	License webspherelibertyv1.WebSphereLibertyApplicationLicense `json:"license,omitempty"`
+
+	NetworkPolicy *webspherelibertyv1.WebSphereLibertyApplicationNetworkPolicy `json:"networkPolicy,omitempty"`
+
+	DisruptionBudget *webspherelibertyv1.WebSphereLibertyApplicationDisruptionBudget `json:"disruptionBudget,omitempty"`
+
+	SemeruCloudCompiler *webspherelibertyv1.WebSphereLibertyApplicationSemeruCloudCompiler `json:"semeruCloudCompiler,omitempty"`
+
+	InstantOn *webspherelibertyv1.WebSphereLibertyApplicationInstantOn `json:"instantOn,omitempty"`
+
+	SecurityContext *corev1.SecurityContext `json:"securityContext,omitempty"`
+
+	SessionCache *webspherelibertyv1.WebSphereLibertyApplicationSessionCache `json:"sessionCache,omitempty"`
+
+	Rollout *webspherelibertyv1.WebSphereLibertyApplicationRollout `json:"rollout,omitempty"`
+
+	GatewayRoute *webspherelibertyv1.WebSphereLibertyApplicationGatewayRoute `json:"gatewayRoute,omitempty"`
+
+	ServiceMesh *webspherelibertyv1.WebSphereLibertyApplicationServiceMesh `json:"serviceMesh,omitempty"`
+}
+
+// Defines how the images of the application are pulled
+type WebSphereLibertyApplicationImage struct {
+	// Policy for pulling container images. Defaults to IfNotPresent.
+	PullPolicy *corev1.PullPolicy `json:"pullPolicy,omitempty"`
+
+	// Name of the Secret to use to pull images from the specified repository. It is not required if the cluster is configured with a global image pull secret.
+	PullSecret *string `json:"pullSecret,omitempty"`
+}
+
+// Defines the health checks of the application container
+type WebSphereLibertyApplicationProbes struct {
+	// Detects if the services need to be restarted. With spec.defaultHealthProbes, defaults to an HTTP probe on the /health/live endpoint and specified fields override the defaults.
+	Liveness *corev1.Probe `json:"liveness,omitempty"`
+
+	// Detects if the services are ready to serve. With spec.defaultHealthProbes, defaults to an HTTP probe on the /health/ready endpoint and specified fields override the defaults.
+	Readiness *corev1.Probe `json:"readiness,omitempty"`
+
+	// Protects slow starting containers from the liveness probe. With spec.defaultHealthProbes, defaults to an HTTP probe on the /health/ready endpoint, which all versions of mpHealth serve, and specified fields override the defaults.
+	Startup *corev1.Probe `json:"startup,omitempty"`
+}
+
+// Specifies the configuration for Single sign-on (SSO) providers to authenticate with.
+type WebSphereLibertyApplicationSSO struct {
+	Providers *WebSphereLibertyApplicationSSOProviders `json:"providers,omitempty"`
+
+	// Common parameters for all SSO providers
+
+	// Specifies a callback protocol, host and port number.
+	RedirectToRPHostAndPort string `json:"redirectToRPHostAndPort,omitempty"`
+
+	// Specifies whether to map a user identifier to a registry user. This parameter applies to all providers.
+	MapToUserRegistry *bool `json:"mapToUserRegistry,omitempty"`
+}
+
+// Defines the single sign-on providers
+type WebSphereLibertyApplicationSSOProviders struct {
+	// +listType=atomic
+	OIDC []webspherelibertyv1.OidcClient `json:"oidc,omitempty"`
+
+	// +listType=atomic
+	Oauth2 []webspherelibertyv1.OAuth2Client `json:"oauth2,omitempty"`
+
+	Github *webspherelibertyv1.GithubLogin `json:"github,omitempty"`
+}
+
+// +kubebuilder:resource:path=webspherelibertyapplications,scope=Namespaced,shortName=wlapp;wlapps
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Image",type="string",JSONPath=".spec.applicationImage",priority=0,description="Absolute name of the deployed image containing registry and tag"
+// +kubebuilder:printcolumn:name="Exposed",type="boolean",JSONPath=".spec.expose",priority=0,description="Specifies whether deployment is exposed externally via default Route"
+// +kubebuilder:printcolumn:name="Reconciled",type="string",JSONPath=".status.conditions[?(@.type=='Reconciled')].status",priority=0,description="Status of the reconcile condition"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status",priority=0,description="Status of the ready condition"
+// +kubebuilder:printcolumn:name="Ready Replicas",type="integer",JSONPath=".status.readyReplicas",priority=0,description="Number of ready pods"
+// +kubebuilder:printcolumn:name="Replicas",type="integer",JSONPath=".status.replicas",priority=1,description="Number of desired pods"
+// +kubebuilder:printcolumn:name="Endpoint",type="string",JSONPath=".status.endpoint",priority=1,description="URL of the exposed application"
+// +kubebuilder:printcolumn:name="Reason",type="string",JSONPath=".status.conditions[?(@.type=='Reconciled')].reason",priority=1,description="Reason for the failure of reconcile condition"
+// +kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.conditions[?(@.type=='Reconciled')].message",priority=1,description="Failure message from reconcile condition"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",priority=0,description="Age of the resource"
+
+// Represents the deployment of an WebSphere Liberty application
+type WebSphereLibertyApplication struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   WebSphereLibertyApplicationSpec                      `json:"spec,omitempty"`
+	Status webspherelibertyv1.WebSphereLibertyApplicationStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// WebSphereLibertyApplicationList contains a list of WebSphereLibertyApplication
+type WebSphereLibertyApplicationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []WebSphereLibertyApplication `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&WebSphereLibertyApplication{}, &WebSphereLibertyApplicationList{})
+}
@@ -20,7 +20,9 @@ import (
 var _ handler.EventHandler = &EnqueueRequestsForCustomIndexField{}
 
 const (
-	indexFieldImageStreamName = "spec.applicationImage"
+	indexFieldImageStreamName         = "spec.applicationImage"
+	indexFieldServerConfigMapNames    = "spec.serverConfig.configMapName"
+	indexFieldServerConfigSecretNames = "spec.serverConfig.secretName"
 )
 
 // EnqueueRequestsForCustomIndexField enqueues reconcile Requests for WebSphereLiberty Applications if the app is relying on
@@ -102,3 +104,40 @@ func (i *ImageStreamMatcher) Match(imageStreamTag metav1.Object) ([]webspherelib
 
 	return apps, nil
 }
+
+// ServerConfigMatcher implements CustomMatcher for ConfigMaps and Secrets referenced in spec.serverConfig
+type ServerConfigMatcher struct {
+	Klient     client.Client
+	IndexField string
+}
+
+// Match returns all applications in the namespace of the input object that use it as server configuration
+func (s *ServerConfigMatcher) Match(obj metav1.Object) ([]webspherelibertyv1.WebSphereLibertyApplication, error) {
+	appList := &webspherelibertyv1.WebSphereLibertyApplicationList{}
+	err := s.Klient.List(context.Background(),
+		appList,
+		client.InNamespace(obj.GetNamespace()),
+		client.MatchingFields{s.IndexField: obj.GetName()})
+	if err != nil {
+		return nil, err
+	}
+	return appList.Items, nil
+}
+
+// getServerConfigSourceNames returns the names of the ConfigMaps or the Secrets referenced in spec.serverConfig
+func getServerConfigSourceNames(instance *webspherelibertyv1.WebSphereLibertyApplication, configMaps bool) []string {
+	if instance.Spec.ServerConfig == nil {
+		return nil
+	}
+	var names []string
+	sources := append([]webspherelibertyv1.WebSphereLibertyApplicationServerConfigSource{}, instance.Spec.ServerConfig.Overrides...)
+	sources = append(sources, instance.Spec.ServerConfig.Defaults...)
+	for _, src := range sources {
+		if configMaps && src.ConfigMapName != "" {
+			names = append(names, src.ConfigMapName)
+		} else if !configMaps && src.SecretName != "" {
+			names = append(names, src.SecretName)
+		}
+	}
+	return names
+}
@@ -0,0 +1,126 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	webspherelibertyv1 "github.com/WASdev/websphere-liberty-operator/api/v1"
+	lutils "github.com/WASdev/websphere-liberty-operator/utils"
+	oputils "github.com/application-stacks/runtime-component-operator/utils"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// ReconcileOperatorConfig loads the configuration of the operator from its ConfigMap whenever the ConfigMap changes. The
+// ConfigMap is only read, and the defaults are used for the keys it does not set.
+type ReconcileOperatorConfig struct {
+	// Client lists the applications to reconcile again when the configuration changes
+	Client   client.Client
+	Recorder record.EventRecorder
+	Log      logr.Logger
+	// ConfigChanges sends the applications to reconcile again when the configuration changes
+	ConfigChanges chan<- event.GenericEvent
+
+	configReader client.Reader
+	namespace    string
+}
+
+// Reconcile validates the operator ConfigMap and applies its configuration. Each invalid key is reported as an Event on the
+// ConfigMap, and the default is used for it.
+func (r *ReconcileOperatorConfig) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	changed, err := r.loadOperatorConfig(ctx, r.configReader, request.NamespacedName)
+	if err != nil || !changed {
+		return reconcile.Result{}, err
+	}
+
+	apps := &webspherelibertyv1.WebSphereLibertyApplicationList{}
+	if err := r.Client.List(ctx, apps); err != nil {
+		return reconcile.Result{}, err
+	}
+	for i := range apps.Items {
+		r.ConfigChanges <- event.GenericEvent{Object: &apps.Items[i]}
+	}
+	return reconcile.Result{}, nil
+}
+
+// LoadOperatorConfig applies the configuration in the operator ConfigMap before the manager is started, so that the applications
+// are not reconciled with the default configuration, and then again with the configured one, whenever the operator restarts
+func (r *ReconcileOperatorConfig) LoadOperatorConfig(ctx context.Context, reader client.Reader) error {
+	_, err := r.loadOperatorConfig(ctx, reader, types.NamespacedName{Name: lutils.OperatorConfigMapName, Namespace: r.namespace})
+	return err
+}
+
+// loadOperatorConfig reads the operator ConfigMap and applies its configuration. It returns true if the configuration changed.
+func (r *ReconcileOperatorConfig) loadOperatorConfig(ctx context.Context, reader client.Reader, key types.NamespacedName) (bool, error) {
+	reqLogger := r.Log.WithValues("Request.Namespace", key.Namespace, "Request.Name", key.Name)
+
+	cm := &corev1.ConfigMap{}
+	if err := reader.Get(ctx, key, cm); err != nil {
+		if !kerrors.IsNotFound(err) {
+			return false, err
+		}
+		cm = nil
+	}
+
+	cfg, errs := lutils.ParseOperatorConfig(cm)
+	for _, err := range errs {
+		reqLogger.Info(err.Error())
+		r.Recorder.Event(cm, corev1.EventTypeWarning, "InvalidConfiguration", err.Error())
+	}
+	if reflect.DeepEqual(cfg, lutils.GetOperatorConfig()) {
+		return false, nil
+	}
+	lutils.SetOperatorConfig(cfg)
+	reqLogger.Info("Loaded the operator configuration")
+	return true, nil
+}
+
+// SetupWithManager watches the operator ConfigMap with its own informer, since the namespace of the operator is not
+// necessarily watched by the manager
+func (r *ReconcileOperatorConfig) SetupWithManager(mgr ctrl.Manager) error {
+	ns, err := oputils.GetOperatorNamespace()
+	// When running the operator locally, the namespace of the operator is not known. Use the first namespace in the `watchNamespaces`.
+	if err != nil || ns == "" {
+		r.Log.V(1).Info("Failed to get the namespace of the operator, using the first watched namespace", "error", err)
+		watchNamespaces, err := oputils.GetWatchNamespaces()
+		if err != nil {
+			return err
+		}
+		if len(watchNamespaces) == 0 || watchNamespaces[0] == "" {
+			return fmt.Errorf("the namespace of the operator ConfigMap can not be determined. Set the OPERATOR_NAMESPACE environment variable")
+		}
+		ns = watchNamespaces[0]
+	}
+	r.namespace = ns
+
+	configCache, err := cache.New(mgr.GetConfig(), cache.Options{Scheme: mgr.GetScheme(), Mapper: mgr.GetRESTMapper(), Namespace: ns})
+	if err != nil {
+		return err
+	}
+	if err := mgr.Add(configCache); err != nil {
+		return err
+	}
+	r.configReader = configCache
+
+	c, err := controller.New("operatorconfig", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+	pred := predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return obj.GetName() == lutils.OperatorConfigMapName
+	})
+	return c.Watch(source.NewKindWithCache(&corev1.ConfigMap{}, configCache), &handler.EnqueueRequestForObject{}, pred)
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"time"
 
 	networkingv1 "k8s.io/api/networking/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
 
 	"github.com/application-stacks/runtime-component-operator/common"
 	"github.com/go-logr/logr"
@@ -16,22 +18,28 @@ import (
 	webspherelibertyv1 "github.com/WASdev/websphere-liberty-operator/api/v1"
 
 	prometheusv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
+	certmanagerv1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
 	imagev1 "github.com/openshift/api/image/v1"
 	routev1 "github.com/openshift/api/route/v1"
 	imageutil "github.com/openshift/library-go/pkg/image/imageutil"
 	"github.com/pkg/errors"
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
@@ -42,77 +50,66 @@ type ReconcileWebSphereLiberty struct {
 	// This client, initialized using mgr.Client() above, is a split client
 	// that reads objects from the cache and writes to the apiserver
 	oputils.ReconcilerBase
-	Log             logr.Logger
-	watchNamespaces []string
+	Log logr.Logger
+	// ConfigChanges receives the applications to reconcile again when the operator configuration changes
+	ConfigChanges <-chan event.GenericEvent
+	// MaxConcurrentReconciles is the maximum number of applications reconciled concurrently. Defaults to 1.
+	MaxConcurrentReconciles int
 }
 
+const transactionRecoveryCheckInterval = 10 * time.Second
+const rolloutCheckInterval = 10 * time.Second
+
 const applicationFinalizer = "finalizer.liberty.websphere.ibm.com"
 
+// applicationKind labels the metrics of the applications
+const applicationKind = "WebSphereLibertyApplication"
+
 // +kubebuilder:rbac:groups=liberty.websphere.ibm.com,resources=webspherelibertyapplications;webspherelibertyapplications/status;webspherelibertyapplications/finalizers,verbs=*,namespace=websphere-liberty-operator
 // +kubebuilder:rbac:groups=apps,resources=deployments;statefulsets,verbs=*,namespace=websphere-liberty-operator
 // +kubebuilder:rbac:groups=apps,resources=deployments/finalizers;statefulsets,verbs=update,namespace=websphere-liberty-operator
 // +kubebuilder:rbac:groups=core,resources=services;secrets;serviceaccounts;configmaps;persistentvolumeclaims,verbs=*,namespace=websphere-liberty-operator
 // +kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=*,namespace=websphere-liberty-operator
-// +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=*,namespace=websphere-liberty-operator
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=*,namespace=websphere-liberty-operator
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=*,namespace=websphere-liberty-operator
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses;networkpolicies,verbs=*,namespace=websphere-liberty-operator
 // +kubebuilder:rbac:groups=route.openshift.io,resources=routes;routes/custom-host,verbs=*,namespace=websphere-liberty-operator
 // +kubebuilder:rbac:groups=image.openshift.io,resources=imagestreams;imagestreamtags,verbs=get;list;watch,namespace=websphere-liberty-operator
 // +kubebuilder:rbac:groups=serving.knative.dev,resources=services,verbs=*,namespace=websphere-liberty-operator
 // +kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors,verbs=*,namespace=websphere-liberty-operator
+// +kubebuilder:rbac:groups=cert-manager.io,resources=certificates,verbs=*,namespace=websphere-liberty-operator
+// +kubebuilder:rbac:groups=infinispan.org,resources=infinispans,verbs=*,namespace=websphere-liberty-operator
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes,verbs=*,namespace=websphere-liberty-operator
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=gateways,verbs=get;list;watch,namespace=websphere-liberty-operator
+// +kubebuilder:rbac:groups=networking.istio.io,resources=virtualservices;destinationrules,verbs=*,namespace=websphere-liberty-operator
+// +kubebuilder:rbac:groups=security.istio.io,resources=peerauthentications,verbs=*,namespace=websphere-liberty-operator
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
 func (r *ReconcileWebSphereLiberty) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
 	reqLogger := r.Log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
 	reqLogger.Info("Reconcile WebSphereLibertyApplication - starting")
-	ns, err := oputils.GetOperatorNamespace()
-	// When running the operator locally, `ns` will be empty string
-	if ns == "" {
-		// Since this method can be called directly from unit test, populate `watchNamespaces`.
-		if r.watchNamespaces == nil {
-			r.watchNamespaces, err = oputils.GetWatchNamespaces()
-			if err != nil {
-				reqLogger.Error(err, "Error getting watch namespace")
-				return reconcile.Result{}, err
-			}
-		}
-		// If the operator is running locally, use the first namespace in the `watchNamespaces`
-		// `watchNamespaces` must have at least one item
-		ns = r.watchNamespaces[0]
-	}
 
-	configMap, err := r.GetOpConfigMap("websphere-liberty-operator", ns)
-	if err != nil {
-		reqLogger.Info("Failed to find websphere-liberty-operator config map")
-		common.Config = common.DefaultOpConfig()
-		configMap = &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "websphere-liberty-operator", Namespace: ns}}
-		configMap.Data = common.Config
-	} else {
-		common.Config.LoadFromConfigMap(configMap)
-	}
-
-	_, err = controllerutil.CreateOrUpdate(context.TODO(), r.GetClient(), configMap, func() error {
-		configMap.Data = common.Config
-		return nil
-	})
-
-	if err != nil {
-		reqLogger.Info("Failed to update websphere-liberty-operator config map")
-	}
+	// The operator configuration is not replaced while the application is reconciled
+	defer lutils.LockOperatorConfig()()
 
 	// Fetch the WebSphereLiberty instance
 	instance := &webspherelibertyv1.WebSphereLibertyApplication{}
 	var ba common.BaseComponent = instance
-	err = r.GetClient().Get(context.TODO(), request.NamespacedName, instance)
+	err := r.GetClient().Get(context.TODO(), request.NamespacedName, instance)
 	if err != nil {
 		if kerrors.IsNotFound(err) {
 			// Request object not found, could have been deleted after reconcile request.
 			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
 			// Return and don't requeue
+			lutils.RecordReconcile(applicationKind, nil)
 			return reconcile.Result{}, nil
 		}
 		// Error reading the object - requeue the request.
+		lutils.RecordReconcile(applicationKind, err)
 		return reconcile.Result{}, err
 	}
+
 	// Check if the WebSphereLibertyApplication instance is marked to be deleted, which is
 	// indicated by the deletion timestamp being set.
 	isInstanceMarkedToBeDeleted := instance.GetDeletionTimestamp() != nil
@@ -121,6 +118,7 @@ func (r *ReconcileWebSphereLiberty) Reconcile(ctx context.Context, request ctrl.
 			// Run finalization logic for applicationFinalizer. If the finalization logic fails, don't remove the
 			// finalizer so that we can retry during the next reconciliation.
 			if err := r.finalizeWebSphereLibertyApplication(reqLogger, instance, instance.Name+"-serviceability", instance.Namespace); err != nil {
+				lutils.RecordReconcile(applicationKind, err)
 				return reconcile.Result{}, err
 			}
 
@@ -128,33 +126,43 @@ func (r *ReconcileWebSphereLiberty) Reconcile(ctx context.Context, request ctrl.
 			instance.SetFinalizers(lutils.Remove(instance.GetFinalizers(), applicationFinalizer))
 			err := r.GetClient().Update(context.TODO(), instance)
 			if err != nil {
+				lutils.RecordReconcile(applicationKind, err)
+				return reconcile.Result{}, err
+			}
+		}
+		lutils.RecordReconcile(applicationKind, nil)
+		return reconcile.Result{}, nil
+	}
+
+	// While reconciliation is paused, only the Paused condition is reported, so that the resources can be edited by hand.
+	// Deletion is not paused, so that the finalizer does not block it.
+	pausedChanged := lutils.SetPausedCondition(instance)
+	if lutils.IsReconcilePaused(instance) {
+		reqLogger.Info("Reconciliation of WebSphereLibertyApplication is paused")
+		if pausedChanged {
+			if err := r.GetClient().Status().Update(context.TODO(), instance); err != nil {
+				lutils.RecordReconcile(applicationKind, err)
 				return reconcile.Result{}, err
 			}
 		}
+		lutils.RecordReconcile(applicationKind, nil)
 		return reconcile.Result{}, nil
 	}
 
 	// Add finalizer for this CR
 	if !lutils.Contains(instance.GetFinalizers(), applicationFinalizer) {
 		if err := r.addFinalizer(reqLogger, instance); err != nil {
+			lutils.RecordReconcile(applicationKind, err)
 			return reconcile.Result{}, err
 		}
 	}
 	instance.Initialize()
 
-	_, err = oputils.Validate(instance)
-	// If there's any validation error, don't bother with requeuing
-	if err != nil {
-		reqLogger.Error(err, "Error validating WebSphereLibertyApplication")
-		r.ManageError(err, common.StatusConditionTypeReconciled, instance)
-		return reconcile.Result{}, nil
-	}
-
 	_, err = lutils.Validate(instance)
 	// If there's any validation error, don't bother with requeuing
 	if err != nil {
 		reqLogger.Error(err, "Error validating WebSphereLibertyApplication")
-		r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+		r.ManageError(lutils.NewReconcileError(lutils.ReconcileReasonInvalidSpec, err), common.StatusConditionTypeReconciled, instance)
 		return reconcile.Result{}, nil
 	}
 
@@ -179,6 +187,9 @@ func (r *ReconcileWebSphereLiberty) Reconcile(ctx context.Context, request ctrl.
 		Namespace: instance.Namespace,
 	}
 
+	// The applications created before the license was introduced are still reconciled, and report that it is not accepted
+	lutils.SetLicenseCondition(instance)
+
 	imageReferenceOld := instance.Status.ImageReference
 	instance.Status.ImageReference = instance.Spec.ApplicationImage
 	if r.IsOpenShift() {
@@ -253,15 +264,23 @@ func (r *ReconcileWebSphereLiberty) Reconcile(ctx context.Context, request ctrl.
 			&appsv1.Deployment{ObjectMeta: defaultMeta},
 			&appsv1.StatefulSet{ObjectMeta: defaultMeta},
 			&autoscalingv1.HorizontalPodAutoscaler{ObjectMeta: defaultMeta},
+			&networkingv1.NetworkPolicy{ObjectMeta: defaultMeta},
+			&policyv1beta1.PodDisruptionBudget{ObjectMeta: defaultMeta},
+			&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: lutils.GetSemeruCompilerName(instance), Namespace: instance.Namespace}},
+			&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: lutils.GetSemeruCompilerName(instance), Namespace: instance.Namespace}},
+			&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: lutils.GetRolloutName(instance), Namespace: instance.Namespace}},
+			&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: lutils.GetRolloutName(instance), Namespace: instance.Namespace}},
 		}
 		err = r.DeleteResources(resources)
 		if err != nil {
 			reqLogger.Error(err, "Failed to clean up non-Knative resources")
 			return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
 		}
+		instance.Status.Rollout = nil
 
 		if ok, _ := r.IsGroupVersionSupported(networkingv1.SchemeGroupVersion.String(), "Ingress"); ok {
 			r.DeleteResource(&networkingv1.Ingress{ObjectMeta: defaultMeta})
+			r.DeleteResource(&networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Name: lutils.GetRolloutName(instance), Namespace: instance.Namespace}})
 		}
 
 		if r.IsOpenShift() {
@@ -276,6 +295,8 @@ func (r *ReconcileWebSphereLiberty) Reconcile(ctx context.Context, request ctrl.
 			ksvc := &servingv1.Service{ObjectMeta: defaultMeta}
 			err = r.CreateOrUpdate(ksvc, instance, func() error {
 				oputils.CustomizeKnativeService(ksvc, instance)
+				lutils.CustomizeSecurityContext(&ksvc.Spec.Template.Spec.PodSpec, instance)
+				lutils.CustomizeInstantOn(&ksvc.Spec.Template.Spec.PodSpec, instance)
 				return nil
 			})
 
@@ -283,9 +304,12 @@ func (r *ReconcileWebSphereLiberty) Reconcile(ctx context.Context, request ctrl.
 				reqLogger.Error(err, "Failed to reconcile Knative Service")
 				return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
 			}
+			instance.Status.Endpoint = lutils.GetKnativeServiceEndpoint(ksvc)
+			lutils.SetKnativeServiceStatus(instance, ksvc)
 			return r.ManageSuccess(common.StatusConditionTypeReconciled, instance)
 		}
-		return r.ManageError(errors.New("failed to reconcile Knative service as operator could not find Knative CRDs"), common.StatusConditionTypeReconciled, instance)
+		err = lutils.NewReconcileError(lutils.ReconcileReasonAPINotSupported, errors.New("failed to reconcile Knative service as operator could not find Knative CRDs"))
+		return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
 	}
 
 	if isKnativeSupported {
@@ -297,9 +321,21 @@ func (r *ReconcileWebSphereLiberty) Reconcile(ctx context.Context, request ctrl.
 		}
 	}
 
+	if ok, err := r.IsGroupVersionSupported(certmanagerv1.SchemeGroupVersion.String(), "Certificate"); err != nil {
+		reqLogger.Error(err, fmt.Sprintf("Failed to check if %s is supported", certmanagerv1.SchemeGroupVersion.String()))
+		r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+	} else if ok {
+		err = r.reconcileCertificates(instance)
+		if err != nil {
+			reqLogger.Error(err, "Failed to reconcile Certificates")
+			return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+		}
+	}
+
 	svc := &corev1.Service{ObjectMeta: defaultMeta}
 	err = r.CreateOrUpdate(svc, instance, func() error {
 		oputils.CustomizeService(svc, ba)
+		lutils.CustomizeStableService(svc, instance)
 		svc.Annotations = oputils.MergeMaps(svc.Annotations, instance.Spec.Service.Annotations)
 		monitoringEnabledLabelName := getMonitoringEnabledLabelName(ba)
 		if instance.Spec.Monitoring != nil {
@@ -335,6 +371,55 @@ func (r *ReconcileWebSphereLiberty) Reconcile(ctx context.Context, request ctrl.
 		r.deletePVC(reqLogger, instance.Name+"-serviceability", instance.Namespace)
 	}
 
+	metricsSecret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: instance.Name + lutils.MetricsCredentialsSecretSuffix, Namespace: instance.Namespace}}
+	if lutils.IsMetricsCredentialsSecretGenerated(instance) {
+		err = r.CreateOrUpdate(metricsSecret, instance, func() error {
+			return lutils.CustomizeMetricsCredentialsSecret(metricsSecret, instance)
+		})
+		if err != nil {
+			reqLogger.Error(err, "Failed to reconcile metrics credentials Secret")
+			return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+		}
+	} else {
+		err = r.DeleteResource(metricsSecret)
+		if err != nil {
+			reqLogger.Error(err, "Failed to delete metrics credentials Secret")
+			return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+		}
+	}
+
+	err = r.reconcileSessionCache(instance)
+	if err != nil {
+		reqLogger.Error(err, "Failed to reconcile session cache")
+		return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+	}
+
+	statefulSetReplicas, err := r.reconcileTransactionRecovery(instance)
+	if err != nil {
+		reqLogger.Error(err, "Failed to reconcile transaction recovery")
+		return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+	}
+
+	// Off OpenShift, the traffic of the Ingress of the application can only be shifted to a new image by the NGINX ingress controller
+	if instance.Spec.Expose != nil && *instance.Spec.Expose && !lutils.IsGatewayRouteEnabled(instance) && !r.IsOpenShift() {
+		if err := lutils.ValidateRolloutIngress(instance); err != nil {
+			reqLogger.Error(err, "Error validating WebSphereLibertyApplication")
+			return r.ManageError(lutils.NewReconcileError(lutils.ReconcileReasonInvalidSpec, err), common.StatusConditionTypeReconciled, instance)
+		}
+	}
+	deploymentImage, err := r.reconcileRollout(instance)
+	if err != nil {
+		reqLogger.Error(err, "Failed to reconcile rollout")
+		return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+	}
+
+	// The Semeru Cloud Compiler runs the image of the application Deployment, which is only known once the rollout is reconciled
+	err = r.reconcileSemeruCompiler(instance)
+	if err != nil {
+		reqLogger.Error(err, "Failed to reconcile Semeru Cloud Compiler")
+		return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+	}
+
 	if instance.Spec.StatefulSet != nil {
 		// Delete Deployment if exists
 		deploy := &appsv1.Deployment{ObjectMeta: defaultMeta}
@@ -359,10 +444,18 @@ func (r *ReconcileWebSphereLiberty) Reconcile(ctx context.Context, request ctrl.
 		statefulSet := &appsv1.StatefulSet{ObjectMeta: defaultMeta}
 		err = r.CreateOrUpdate(statefulSet, instance, func() error {
 			oputils.CustomizeStatefulSet(statefulSet, instance)
+			if statefulSetReplicas != nil {
+				statefulSet.Spec.Replicas = statefulSetReplicas
+			}
 			oputils.CustomizePodSpec(&statefulSet.Spec.Template, instance)
 			oputils.CustomizePersistence(statefulSet, instance)
+			lutils.CustomizeTransactionRecovery(statefulSet, instance)
 			lutils.CustomizeLibertyEnv(&statefulSet.Spec.Template, instance)
+			lutils.CustomizeSecurityContext(&statefulSet.Spec.Template.Spec, instance)
+			lutils.CustomizeInstantOn(&statefulSet.Spec.Template.Spec, instance)
 			lutils.CustomizeLibertyAnnotations(&statefulSet.Spec.Template, instance)
+			lutils.CustomizeTopologySpreadConstraints(&statefulSet.Spec.Template, instance)
+			lutils.CustomizeServiceMesh(&statefulSet.Spec.Template, instance)
 			if instance.Spec.SSO != nil {
 				err = lutils.CustomizeEnvSSO(&statefulSet.Spec.Template, instance, r.GetClient(), r.IsOpenShift())
 				if err != nil {
@@ -371,6 +464,17 @@ func (r *ReconcileWebSphereLiberty) Reconcile(ctx context.Context, request ctrl.
 				}
 			}
 			lutils.ConfigureServiceability(&statefulSet.Spec.Template, instance)
+			lutils.CustomizeMetricsCredentials(&statefulSet.Spec.Template, instance)
+			err = lutils.CustomizeSessionCache(&statefulSet.Spec.Template, instance, r.GetClient())
+			if err != nil {
+				reqLogger.Error(err, "Failed to reconcile session cache configuration")
+				return err
+			}
+			err = lutils.CustomizeServerConfig(&statefulSet.Spec.Template, instance, r.GetClient())
+			if err != nil {
+				reqLogger.Error(err, "Failed to reconcile server configuration")
+				return err
+			}
 
 			return nil
 		})
@@ -378,6 +482,7 @@ func (r *ReconcileWebSphereLiberty) Reconcile(ctx context.Context, request ctrl.
 			reqLogger.Error(err, "Failed to reconcile StatefulSet")
 			return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
 		}
+		lutils.SetStatefulSetStatus(instance, statefulSet)
 
 	} else {
 		// Delete StatefulSet if exists
@@ -398,32 +503,50 @@ func (r *ReconcileWebSphereLiberty) Reconcile(ctx context.Context, request ctrl.
 		}
 		deploy := &appsv1.Deployment{ObjectMeta: defaultMeta}
 		err = r.CreateOrUpdate(deploy, instance, func() error {
-			oputils.CustomizeDeployment(deploy, instance)
-			oputils.CustomizePodSpec(&deploy.Spec.Template, instance)
-			lutils.CustomizeLibertyEnv(&deploy.Spec.Template, instance)
-			lutils.CustomizeLibertyAnnotations(&deploy.Spec.Template, instance)
-			if instance.Spec.SSO != nil {
-				err = lutils.CustomizeEnvSSO(&deploy.Spec.Template, instance, r.GetClient(), r.IsOpenShift())
-				if err != nil {
-					reqLogger.Error(err, "Failed to reconcile Single sign-on configuration")
-					return err
-				}
+			// The selector of an existing Deployment is immutable
+			selector := deploy.Spec.Selector.DeepCopy()
+			if err := r.customizeDeployment(deploy, instance); err != nil {
+				return err
 			}
-
-			lutils.ConfigureServiceability(&deploy.Spec.Template, instance)
+			// The Deployment keeps its image while a new image is rolled out
+			deploy.Spec.Template.Spec.Containers[0].Image = deploymentImage
+			lutils.CustomizeStableTrack(deploy, selector, instance)
 			return nil
 		})
 		if err != nil {
 			reqLogger.Error(err, "Failed to reconcile Deployment")
 			return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
 		}
+		lutils.SetDeploymentStatus(instance, deploy)
 
 	}
 
-	if instance.Spec.Autoscaling != nil {
+	if lutils.IsAutoscalingV2beta2Needed(instance) {
+		// Memory utilization, additional metrics and behavior are only available through the autoscaling/v2beta2 API
+		if ok, err := r.IsGroupVersionSupported(autoscalingv2beta2.SchemeGroupVersion.String(), "HorizontalPodAutoscaler"); err != nil {
+			reqLogger.Error(err, fmt.Sprintf("Failed to check if %s is supported", autoscalingv2beta2.SchemeGroupVersion.String()))
+			return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+		} else if !ok {
+			err = lutils.NewReconcileError(lutils.ReconcileReasonAPINotSupported,
+				fmt.Errorf("spec.autoscaling.targetMemoryUtilizationPercentage, spec.autoscaling.metrics and spec.autoscaling.behavior require the %s API, which is not served by the cluster. Only spec.autoscaling.targetCPUUtilizationPercentage is supported",
+					autoscalingv2beta2.SchemeGroupVersion.String()))
+			reqLogger.Error(err, "Failed to reconcile HorizontalPodAutoscaler")
+			return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+		}
+		hpa := &autoscalingv2beta2.HorizontalPodAutoscaler{ObjectMeta: defaultMeta}
+		err = r.CreateOrUpdate(hpa, instance, func() error {
+			lutils.CustomizeHPA(hpa, instance)
+			return nil
+		})
+
+		if err != nil {
+			reqLogger.Error(err, "Failed to reconcile HorizontalPodAutoscaler")
+			return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+		}
+	} else if instance.Spec.Autoscaling != nil {
 		hpa := &autoscalingv1.HorizontalPodAutoscaler{ObjectMeta: defaultMeta}
 		err = r.CreateOrUpdate(hpa, instance, func() error {
-			oputils.CustomizeHPA(hpa, instance)
+			lutils.CustomizeCPUHPA(hpa, instance)
 			return nil
 		})
 
@@ -440,11 +563,91 @@ func (r *ReconcileWebSphereLiberty) Reconcile(ctx context.Context, request ctrl.
 		}
 	}
 
+	if lutils.IsPodDisruptionBudgetNeeded(instance) {
+		pdb := &policyv1beta1.PodDisruptionBudget{ObjectMeta: defaultMeta}
+		err = r.CreateOrUpdate(pdb, instance, func() error {
+			lutils.CustomizePodDisruptionBudget(pdb, instance)
+			return nil
+		})
+
+		if err != nil {
+			reqLogger.Error(err, "Failed to reconcile PodDisruptionBudget")
+			return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+		}
+	} else {
+		pdb := &policyv1beta1.PodDisruptionBudget{ObjectMeta: defaultMeta}
+		err = r.DeleteResource(pdb)
+		if err != nil {
+			reqLogger.Error(err, "Failed to delete PodDisruptionBudget")
+			return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+		}
+	}
+
+	if instance.Spec.NetworkPolicy != nil {
+		networkPolicy := &networkingv1.NetworkPolicy{ObjectMeta: defaultMeta}
+		err = r.CreateOrUpdate(networkPolicy, instance, func() error {
+			lutils.CustomizeNetworkPolicy(networkPolicy, r.IsOpenShift(), instance)
+			return nil
+		})
+
+		if err != nil {
+			reqLogger.Error(err, "Failed to reconcile NetworkPolicy")
+			return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+		}
+	} else {
+		networkPolicy := &networkingv1.NetworkPolicy{ObjectMeta: defaultMeta}
+		err = r.DeleteResource(networkPolicy)
+		if err != nil {
+			reqLogger.Error(err, "Failed to delete NetworkPolicy")
+			return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+		}
+	}
+
+	instance.Status.Endpoint = ""
+	if httpRouteGVK, ok, err := r.getHTTPRouteGroupVersionKind(); err != nil {
+		reqLogger.Error(err, "Failed to check if the Gateway API is supported")
+		r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+	} else if ok {
+		httpRoute := &unstructured.Unstructured{}
+		httpRoute.SetGroupVersionKind(httpRouteGVK)
+		httpRoute.SetName(instance.Name)
+		httpRoute.SetNamespace(instance.Namespace)
+		if lutils.IsGatewayRouteEnabled(instance) {
+			err = r.CreateOrUpdate(httpRoute, instance, func() error {
+				return lutils.CustomizeHTTPRoute(httpRoute, instance)
+			})
+			if err != nil {
+				reqLogger.Error(err, "Failed to reconcile HTTPRoute")
+				return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+			}
+			// The endpoint is only derived from the hostnames of the HTTPRoute when the Gateway cannot be read
+			gateway := &unstructured.Unstructured{}
+			gateway.SetGroupVersionKind(httpRouteGVK.GroupVersion().WithKind("Gateway"))
+			err = r.GetClient().Get(context.TODO(), types.NamespacedName{Name: instance.Spec.GatewayRoute.Gateway.Name, Namespace: lutils.GetGatewayNamespace(instance)}, gateway)
+			if err != nil && !kerrors.IsNotFound(err) && !kerrors.IsForbidden(err) {
+				reqLogger.Error(err, "Failed to get Gateway")
+				return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+			}
+			instance.Status.Endpoint = lutils.GetHTTPRouteEndpoint(instance, gateway)
+		} else {
+			err = r.DeleteResource(httpRoute)
+			if err != nil {
+				reqLogger.Error(err, "Failed to delete HTTPRoute")
+				return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+			}
+		}
+	} else if lutils.IsGatewayRouteEnabled(instance) {
+		err = lutils.NewReconcileError(lutils.ReconcileReasonAPINotSupported, errors.New("failed to reconcile HTTPRoute as operator could not find Gateway API CRDs"))
+		return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+	}
+
+	// The application is exposed through a Route or an Ingress unless it is exposed through the Gateway API
+	exposeRoute := instance.Spec.Expose != nil && *instance.Spec.Expose && !lutils.IsGatewayRouteEnabled(instance)
 	if ok, err := r.IsGroupVersionSupported(routev1.SchemeGroupVersion.String(), "Route"); err != nil {
 		reqLogger.Error(err, fmt.Sprintf("Failed to check if %s is supported", routev1.SchemeGroupVersion.String()))
 		r.ManageError(err, common.StatusConditionTypeReconciled, instance)
 	} else if ok {
-		if instance.Spec.Expose != nil && *instance.Spec.Expose {
+		if exposeRoute {
 			route := &routev1.Route{ObjectMeta: defaultMeta}
 			err = r.CreateOrUpdate(route, instance, func() error {
 				key, cert, caCert, destCACert, err := r.GetRouteTLSValues(ba)
@@ -452,6 +655,7 @@ func (r *ReconcileWebSphereLiberty) Reconcile(ctx context.Context, request ctrl.
 					return err
 				}
 				oputils.CustomizeRoute(route, instance, key, cert, caCert, destCACert)
+				lutils.CustomizeRolloutRoute(route, instance)
 
 				return nil
 			})
@@ -459,6 +663,7 @@ func (r *ReconcileWebSphereLiberty) Reconcile(ctx context.Context, request ctrl.
 				reqLogger.Error(err, "Failed to reconcile Route")
 				return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
 			}
+			instance.Status.Endpoint = lutils.GetRouteEndpoint(route)
 
 		} else {
 			route := &routev1.Route{ObjectMeta: defaultMeta}
@@ -474,16 +679,17 @@ func (r *ReconcileWebSphereLiberty) Reconcile(ctx context.Context, request ctrl.
 			reqLogger.Error(err, fmt.Sprintf("Failed to check if %s is supported", networkingv1.SchemeGroupVersion.String()))
 			r.ManageError(err, common.StatusConditionTypeReconciled, instance)
 		} else if ok {
-			if instance.Spec.Expose != nil && *instance.Spec.Expose {
+			if exposeRoute {
 				ing := &networkingv1.Ingress{ObjectMeta: defaultMeta}
 				err = r.CreateOrUpdate(ing, instance, func() error {
-					oputils.CustomizeIngress(ing, instance)
+					lutils.CustomizeIngress(ing, instance)
 					return nil
 				})
 				if err != nil {
 					reqLogger.Error(err, "Failed to reconcile Ingress")
 					return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
 				}
+				instance.Status.Endpoint = lutils.GetIngressEndpoint(ing)
 			} else {
 				ing := &networkingv1.Ingress{ObjectMeta: defaultMeta}
 				err = r.DeleteResource(ing)
@@ -492,9 +698,33 @@ func (r *ReconcileWebSphereLiberty) Reconcile(ctx context.Context, request ctrl.
 					return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
 				}
 			}
+
+			rolloutIng := &networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Name: lutils.GetRolloutName(instance), Namespace: instance.Namespace}}
+			if exposeRoute && lutils.GetRolloutWeight(instance) > 0 {
+				err = r.CreateOrUpdate(rolloutIng, instance, func() error {
+					lutils.CustomizeIngress(rolloutIng, instance)
+					lutils.CustomizeRolloutIngress(rolloutIng, instance)
+					return nil
+				})
+				if err != nil {
+					reqLogger.Error(err, "Failed to reconcile rollout Ingress")
+					return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+				}
+			} else {
+				err = r.DeleteResource(rolloutIng)
+				if err != nil {
+					reqLogger.Error(err, "Failed to delete rollout Ingress")
+					return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+				}
+			}
 		}
 	}
 
+	if err := r.reconcileServiceMesh(instance); err != nil {
+		reqLogger.Error(err, "Failed to reconcile service mesh resources")
+		return r.ManageError(err, common.StatusConditionTypeReconciled, instance)
+	}
+
 	if ok, err := r.IsGroupVersionSupported(prometheusv1.SchemeGroupVersion.String(), "ServiceMonitor"); err != nil {
 		reqLogger.Error(err, fmt.Sprintf("Failed to check if %s is supported", prometheusv1.SchemeGroupVersion.String()))
 		r.ManageError(err, common.StatusConditionTypeReconciled, instance)
@@ -503,6 +733,7 @@ func (r *ReconcileWebSphereLiberty) Reconcile(ctx context.Context, request ctrl.
 			sm := &prometheusv1.ServiceMonitor{ObjectMeta: defaultMeta}
 			err = r.CreateOrUpdate(sm, instance, func() error {
 				oputils.CustomizeServiceMonitor(sm, instance)
+				lutils.CustomizeServiceMonitor(sm, instance)
 				return nil
 			})
 			if err != nil {
@@ -523,7 +754,16 @@ func (r *ReconcileWebSphereLiberty) Reconcile(ctx context.Context, request ctrl.
 	}
 
 	reqLogger.Info("Reconcile WebSphereLibertyApplication - completed")
-	return r.ManageSuccess(common.StatusConditionTypeReconciled, instance)
+	result, err := r.ManageSuccess(common.StatusConditionTypeReconciled, instance)
+	if err == nil && instance.Status.TransactionRecovery != nil {
+		// Wait for the removed pod to terminate and for its transactions to be recovered before scaling further
+		result.RequeueAfter = transactionRecoveryCheckInterval
+	}
+	if err == nil && lutils.IsRolloutInProgress(instance) {
+		// Move the rollout to its next step once the current pause elapses
+		result.RequeueAfter = rolloutCheckInterval
+	}
+	return result, err
 }
 
 func (r *ReconcileWebSphereLiberty) SetupWithManager(mgr ctrl.Manager) error {
@@ -542,6 +782,14 @@ func (r *ReconcileWebSphereLiberty) SetupWithManager(mgr ctrl.Manager) error {
 		return nil
 	})
 
+	mgr.GetFieldIndexer().IndexField(context.Background(), &webspherelibertyv1.WebSphereLibertyApplication{}, indexFieldServerConfigMapNames, func(obj client.Object) []string {
+		return getServerConfigSourceNames(obj.(*webspherelibertyv1.WebSphereLibertyApplication), true)
+	})
+
+	mgr.GetFieldIndexer().IndexField(context.Background(), &webspherelibertyv1.WebSphereLibertyApplication{}, indexFieldServerConfigSecretNames, func(obj client.Object) []string {
+		return getServerConfigSourceNames(obj.(*webspherelibertyv1.WebSphereLibertyApplication), false)
+	})
+
 	watchNamespaces, err := oputils.GetWatchNamespaces()
 	if err != nil {
 		r.Log.Error(err, "Failed to get watch namespace")
@@ -556,8 +804,9 @@ func (r *ReconcileWebSphereLiberty) SetupWithManager(mgr ctrl.Manager) error {
 
 	pred := predicate.Funcs{
 		UpdateFunc: func(e event.UpdateEvent) bool {
-			// Ignore updates to CR status in which case metadata.Generation does not change
-			return e.ObjectOld.GetGeneration() != e.ObjectNew.GetGeneration() && (isClusterWide || watchNamespacesMap[e.ObjectNew.GetNamespace()])
+			// Ignore updates to CR status in which case metadata.Generation does not change, unless reconciliation is paused or resumed
+			return (e.ObjectOld.GetGeneration() != e.ObjectNew.GetGeneration() || lutils.IsReconcilePausedChanged(e.ObjectOld, e.ObjectNew)) &&
+				(isClusterWide || watchNamespacesMap[e.ObjectNew.GetNamespace()])
 		},
 		CreateFunc: func(e event.CreateEvent) bool {
 			return isClusterWide || watchNamespacesMap[e.Object.GetNamespace()]
@@ -585,10 +834,11 @@ func (r *ReconcileWebSphereLiberty) SetupWithManager(mgr ctrl.Manager) error {
 		},
 	}
 
-	predSubResWithGenCheck := predicate.Funcs{
+	predWorkload := predicate.Funcs{
 		UpdateFunc: func(e event.UpdateEvent) bool {
-			// Ignore updates to CR status in which case metadata.Generation does not change
-			return (isClusterWide || watchNamespacesMap[e.ObjectOld.GetNamespace()]) && e.ObjectOld.GetGeneration() != e.ObjectNew.GetGeneration()
+			// Ignore status updates of the Deployment or StatefulSet unless its replicas or their readiness change
+			return (isClusterWide || watchNamespacesMap[e.ObjectOld.GetNamespace()]) &&
+				(e.ObjectOld.GetGeneration() != e.ObjectNew.GetGeneration() || isWorkloadStatusChanged(e.ObjectOld, e.ObjectNew))
 		},
 		CreateFunc: func(e event.CreateEvent) bool {
 			return false
@@ -601,12 +851,47 @@ func (r *ReconcileWebSphereLiberty) SetupWithManager(mgr ctrl.Manager) error {
 		},
 	}
 
+	predServerConfig := predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return isClusterWide || watchNamespacesMap[e.ObjectNew.GetNamespace()]
+		},
+		CreateFunc: func(e event.CreateEvent) bool {
+			return isClusterWide || watchNamespacesMap[e.Object.GetNamespace()]
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return isClusterWide || watchNamespacesMap[e.Object.GetNamespace()]
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	}
+
 	b := ctrl.NewControllerManagedBy(mgr).For(&webspherelibertyv1.WebSphereLibertyApplication{}, builder.WithPredicates(pred)).
+		WithOptions(controller.Options{MaxConcurrentReconciles: r.MaxConcurrentReconciles}).
 		Owns(&corev1.Service{}, builder.WithPredicates(predSubResource)).
 		Owns(&corev1.Secret{}, builder.WithPredicates(predSubResource)).
-		Owns(&appsv1.Deployment{}, builder.WithPredicates(predSubResWithGenCheck)).
-		Owns(&appsv1.StatefulSet{}, builder.WithPredicates(predSubResWithGenCheck)).
-		Owns(&autoscalingv1.HorizontalPodAutoscaler{}, builder.WithPredicates(predSubResource))
+		Owns(&appsv1.Deployment{}, builder.WithPredicates(predWorkload)).
+		Owns(&appsv1.StatefulSet{}, builder.WithPredicates(predWorkload)).
+		Owns(&autoscalingv1.HorizontalPodAutoscaler{}, builder.WithPredicates(predSubResource)).
+		Owns(&networkingv1.NetworkPolicy{}, builder.WithPredicates(predSubResource)).
+		Owns(&policyv1beta1.PodDisruptionBudget{}, builder.WithPredicates(predSubResource)).
+		Owns(&batchv1.Job{}, builder.WithPredicates(predSubResource)).
+		Watches(&source.Kind{Type: &corev1.ConfigMap{}}, &EnqueueRequestsForCustomIndexField{
+			Matcher: &ServerConfigMatcher{
+				Klient:     mgr.GetClient(),
+				IndexField: indexFieldServerConfigMapNames,
+			},
+		}, builder.WithPredicates(predServerConfig)).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, &EnqueueRequestsForCustomIndexField{
+			Matcher: &ServerConfigMatcher{
+				Klient:     mgr.GetClient(),
+				IndexField: indexFieldServerConfigSecretNames,
+			},
+		}, builder.WithPredicates(predServerConfig))
+
+	if r.ConfigChanges != nil {
+		b = b.Watches(&source.Channel{Source: r.ConfigChanges}, &handler.EnqueueRequestForObject{})
+	}
 
 	ok, _ := r.IsGroupVersionSupported(routev1.SchemeGroupVersion.String(), "Route")
 	if ok {
@@ -620,10 +905,27 @@ func (r *ReconcileWebSphereLiberty) SetupWithManager(mgr ctrl.Manager) error {
 	if ok {
 		b = b.Owns(&servingv1.Service{}, builder.WithPredicates(predSubResource))
 	}
+	ok, _ = r.IsGroupVersionSupported(lutils.VirtualServiceGroupVersionKind.GroupVersion().String(), lutils.VirtualServiceGroupVersionKind.Kind)
+	if ok {
+		for _, gvk := range []schema.GroupVersionKind{lutils.VirtualServiceGroupVersionKind, lutils.DestinationRuleGroupVersionKind, lutils.PeerAuthenticationGroupVersionKind} {
+			obj := &unstructured.Unstructured{}
+			obj.SetGroupVersionKind(gvk)
+			b = b.Owns(obj, builder.WithPredicates(predSubResource))
+		}
+	}
+	if httpRouteGVK, ok, _ := r.getHTTPRouteGroupVersionKind(); ok {
+		httpRoute := &unstructured.Unstructured{}
+		httpRoute.SetGroupVersionKind(httpRouteGVK)
+		b = b.Owns(httpRoute, builder.WithPredicates(predSubResource))
+	}
 	ok, _ = r.IsGroupVersionSupported(prometheusv1.SchemeGroupVersion.String(), "ServiceMonitor")
 	if ok {
 		b = b.Owns(&prometheusv1.ServiceMonitor{}, builder.WithPredicates(predSubResource))
 	}
+	ok, _ = r.IsGroupVersionSupported(certmanagerv1.SchemeGroupVersion.String(), "Certificate")
+	if ok {
+		b = b.Owns(&certmanagerv1.Certificate{}, builder.WithPredicates(predSubResource))
+	}
 	ok, _ = r.IsGroupVersionSupported(imagev1.SchemeGroupVersion.String(), "ImageStream")
 	if ok {
 		b = b.Watches(&source.Kind{Type: &imagev1.ImageStream{}}, &EnqueueRequestsForCustomIndexField{
@@ -636,6 +938,42 @@ func (r *ReconcileWebSphereLiberty) SetupWithManager(mgr ctrl.Manager) error {
 	return b.Complete(r)
 }
 
+// isWorkloadStatusChanged returns true if the replica counts reported in the status of a Deployment or StatefulSet changed
+func isWorkloadStatusChanged(oldObj client.Object, newObj client.Object) bool {
+	switch o := oldObj.(type) {
+	case *appsv1.Deployment:
+		n, ok := newObj.(*appsv1.Deployment)
+		return ok && (o.Status.ObservedGeneration != n.Status.ObservedGeneration || o.Status.ReadyReplicas != n.Status.ReadyReplicas ||
+			o.Status.UpdatedReplicas != n.Status.UpdatedReplicas || o.Status.Replicas != n.Status.Replicas)
+	case *appsv1.StatefulSet:
+		n, ok := newObj.(*appsv1.StatefulSet)
+		return ok && (o.Status.ObservedGeneration != n.Status.ObservedGeneration || o.Status.ReadyReplicas != n.Status.ReadyReplicas ||
+			o.Status.UpdatedReplicas != n.Status.UpdatedReplicas || o.Status.Replicas != n.Status.Replicas)
+	}
+	return false
+}
+
+// ManageSuccess records the generation that was reconciled and sets the Ready condition from the readiness of the resources of
+// the application before marking it as reconciled
+func (r *ReconcileWebSphereLiberty) ManageSuccess(conditionType common.StatusConditionType, ba common.BaseComponent) (ctrl.Result, error) {
+	if instance, ok := ba.(*webspherelibertyv1.WebSphereLibertyApplication); ok {
+		instance.Status.ObservedGeneration = instance.Generation
+		lutils.SetReadyCondition(instance)
+		lutils.RecordReconcile(applicationKind, nil)
+	}
+	return r.ReconcilerBase.ManageSuccess(conditionType, ba)
+}
+
+// ManageError records the generation that failed to reconcile and marks the application as not ready before reporting the error
+func (r *ReconcileWebSphereLiberty) ManageError(issue error, conditionType common.StatusConditionType, ba common.BaseComponent) (ctrl.Result, error) {
+	if instance, ok := ba.(*webspherelibertyv1.WebSphereLibertyApplication); ok {
+		instance.Status.ObservedGeneration = instance.Generation
+		lutils.SetStatusCondition(instance, webspherelibertyv1.StatusConditionTypeReady, corev1.ConditionFalse, lutils.StatusReasonReconcileFailed, issue.Error())
+		lutils.RecordReconcile(applicationKind, issue)
+	}
+	return r.ReconcilerBase.ManageError(issue, conditionType, ba)
+}
+
 func getMonitoringEnabledLabelName(ba common.BaseComponent) string {
 	return "monitor." + ba.GetGroupName() + "/enabled"
 }
@@ -659,6 +997,430 @@ func (r *ReconcileWebSphereLiberty) addFinalizer(reqLogger logr.Logger, wlapp *w
 	return nil
 }
 
+// reconcileSemeruCompiler creates the Deployment and the Service of the Semeru Cloud Compiler when it is enabled, deletes them
+// otherwise, and reports the readiness of the compiler in the status
+func (r *ReconcileWebSphereLiberty) reconcileSemeruCompiler(instance *webspherelibertyv1.WebSphereLibertyApplication) error {
+	compilerMeta := metav1.ObjectMeta{Name: lutils.GetSemeruCompilerName(instance), Namespace: instance.Namespace}
+	deploy := &appsv1.Deployment{ObjectMeta: compilerMeta}
+	svc := &corev1.Service{ObjectMeta: compilerMeta}
+
+	if instance.Spec.SemeruCloudCompiler == nil {
+		instance.Status.SemeruCompiler = nil
+		return r.DeleteResources([]client.Object{deploy, svc})
+	}
+
+	err := r.CreateOrUpdate(deploy, instance, func() error {
+		lutils.CustomizeSemeruCompilerDeployment(deploy, instance)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	err = r.CreateOrUpdate(svc, instance, func() error {
+		lutils.CustomizeSemeruCompilerService(svc, instance)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	instance.Status.SemeruCompiler = &webspherelibertyv1.SemeruCompilerStatus{
+		ServiceHostname: lutils.GetSemeruCompilerHost(instance),
+		Ready:           lutils.IsSemeruCompilerReady(deploy),
+	}
+	return nil
+}
+
+// customizeDeployment applies the configuration of the application to a Deployment that runs the application image
+func (r *ReconcileWebSphereLiberty) customizeDeployment(deploy *appsv1.Deployment, instance *webspherelibertyv1.WebSphereLibertyApplication) error {
+	oputils.CustomizeDeployment(deploy, instance)
+	oputils.CustomizePodSpec(&deploy.Spec.Template, instance)
+	lutils.CustomizeLibertyEnv(&deploy.Spec.Template, instance)
+	lutils.CustomizeSecurityContext(&deploy.Spec.Template.Spec, instance)
+	lutils.CustomizeInstantOn(&deploy.Spec.Template.Spec, instance)
+	lutils.CustomizeLibertyAnnotations(&deploy.Spec.Template, instance)
+	lutils.CustomizeTopologySpreadConstraints(&deploy.Spec.Template, instance)
+	lutils.CustomizeServiceMesh(&deploy.Spec.Template, instance)
+	if instance.Spec.SSO != nil {
+		err := lutils.CustomizeEnvSSO(&deploy.Spec.Template, instance, r.GetClient(), r.IsOpenShift())
+		if err != nil {
+			return errors.Wrap(err, "failed to reconcile Single sign-on configuration")
+		}
+	}
+
+	lutils.ConfigureServiceability(&deploy.Spec.Template, instance)
+	lutils.CustomizeMetricsCredentials(&deploy.Spec.Template, instance)
+	err := lutils.CustomizeSessionCache(&deploy.Spec.Template, instance, r.GetClient())
+	if err != nil {
+		return errors.Wrap(err, "failed to reconcile session cache configuration")
+	}
+	err = lutils.CustomizeServerConfig(&deploy.Spec.Template, instance, r.GetClient())
+	if err != nil {
+		return errors.Wrap(err, "failed to reconcile server configuration")
+	}
+	return nil
+}
+
+// reconcileRollout runs a new application image in a separate Deployment and Service when a rollout strategy is specified,
+// moves the rollout forward, and returns the image of the application Deployment. The application Deployment keeps its
+// image until the new image is promoted and all the traffic is switched to it, and is updated in place otherwise.
+func (r *ReconcileWebSphereLiberty) reconcileRollout(instance *webspherelibertyv1.WebSphereLibertyApplication) (string, error) {
+	rolloutMeta := metav1.ObjectMeta{Name: lutils.GetRolloutName(instance), Namespace: instance.Namespace}
+	deploy := &appsv1.Deployment{ObjectMeta: rolloutMeta}
+	svc := &corev1.Service{ObjectMeta: rolloutMeta}
+
+	image := instance.Status.ImageReference
+	if instance.Spec.Rollout == nil || instance.Spec.StatefulSet != nil {
+		instance.Status.Rollout = nil
+		return image, r.DeleteResources([]client.Object{deploy, svc})
+	}
+
+	stable := &appsv1.Deployment{}
+	err := r.GetClient().Get(context.TODO(), types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}, stable)
+	if kerrors.IsNotFound(err) {
+		// The first image is deployed in place
+		instance.Status.Rollout = &webspherelibertyv1.RolloutStatus{StableImage: image}
+		return image, r.DeleteResources([]client.Object{deploy, svc})
+	} else if err != nil {
+		return "", err
+	}
+	if instance.Status.Rollout == nil {
+		instance.Status.Rollout = &webspherelibertyv1.RolloutStatus{StableImage: stable.Spec.Template.Spec.Containers[0].Image}
+	}
+	rs := instance.Status.Rollout
+
+	if rs.Phase == webspherelibertyv1.RolloutPhasePromoting && rs.Weight == 100 &&
+		stable.Spec.Template.Spec.Containers[0].Image == rs.Image && lutils.IsDeploymentReady(stable) {
+		rs.StableImage = rs.Image
+		rs.Phase = webspherelibertyv1.RolloutPhaseCompleted
+		rs.Weight = 0
+	}
+	if image == rs.StableImage || (rs.Phase == webspherelibertyv1.RolloutPhaseAborted && image == rs.Image) {
+		return rs.StableImage, r.DeleteResources([]client.Object{deploy, svc})
+	}
+	if image != rs.Image || !lutils.IsRolloutInProgress(instance) {
+		// The rollout starts once the pods of the application Deployment can be told apart from the pods of the new image. The
+		// application Deployment is reconciled again when its pods are ready.
+		if !lutils.IsStableTrackReady(stable) {
+			return rs.StableImage, r.DeleteResources([]client.Object{deploy, svc})
+		}
+		lutils.StartRollout(instance, image, metav1.Now())
+		rs = instance.Status.Rollout
+	}
+
+	// Deployments created before their pods were selected by the track label cannot be updated, since their selector is immutable
+	current := &appsv1.Deployment{}
+	err = r.GetClient().Get(context.TODO(), types.NamespacedName{Name: deploy.Name, Namespace: deploy.Namespace}, current)
+	if err == nil && current.Spec.Selector != nil && current.Spec.Selector.MatchLabels[lutils.RolloutTrackLabel] == "" {
+		if err := r.DeleteResource(current); err != nil {
+			return "", err
+		}
+	} else if err != nil && !kerrors.IsNotFound(err) {
+		return "", err
+	}
+
+	replicas := int32(1)
+	if stable.Spec.Replicas != nil {
+		replicas = *stable.Spec.Replicas
+	}
+	err = r.CreateOrUpdate(deploy, instance, func() error {
+		if err := r.customizeDeployment(deploy, instance); err != nil {
+			return err
+		}
+		lutils.CustomizeRolloutDeployment(deploy, instance, lutils.GetRolloutReplicas(instance, replicas))
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	err = r.CreateOrUpdate(svc, instance, func() error {
+		oputils.CustomizeService(svc, instance)
+		lutils.CustomizeRolloutService(svc, instance)
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	lutils.UpdateRolloutStatus(instance, deploy, metav1.Now())
+	if rs.Phase == webspherelibertyv1.RolloutPhaseAborted {
+		r.Log.Info("Aborted the rollout of the application image, because its pods are not ready", "image", rs.Image)
+		return rs.StableImage, r.DeleteResources([]client.Object{deploy, svc})
+	}
+	if rs.Phase == webspherelibertyv1.RolloutPhasePromoting && rs.Weight == 100 {
+		return rs.Image, nil
+	}
+	return rs.StableImage, nil
+}
+
+// reconcileTransactionRecovery creates the Liberty transaction recovery configuration and returns the number of replicas of the
+// StatefulSet. On scale down, pods are removed one at a time, and the pod is kept out of the StatefulSet until a Job has
+// recovered its in-doubt transactions from its persisted storage. A nil number of replicas keeps the default.
+func (r *ReconcileWebSphereLiberty) reconcileTransactionRecovery(instance *webspherelibertyv1.WebSphereLibertyApplication) (*int32, error) {
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: lutils.GetTransactionRecoveryConfigMapName(instance), Namespace: instance.Namespace}}
+	if !lutils.IsTransactionRecoveryEnabled(instance) {
+		if recovery := instance.Status.TransactionRecovery; recovery != nil && recovery.Job != "" {
+			job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: recovery.Job, Namespace: instance.Namespace}}
+			if err := r.deleteTransactionRecoveryJob(job); err != nil {
+				return nil, err
+			}
+		}
+		instance.Status.TransactionRecovery = nil
+		return nil, r.DeleteResource(cm)
+	}
+
+	err := r.CreateOrUpdate(cm, instance, func() error {
+		lutils.CustomizeTransactionRecoveryConfigMap(cm, instance)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	replicas := int32(1)
+	if instance.GetReplicas() != nil {
+		replicas = *instance.GetReplicas()
+	}
+
+	statefulSet := &appsv1.StatefulSet{}
+	err = r.GetClient().Get(context.TODO(), types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}, statefulSet)
+	if kerrors.IsNotFound(err) {
+		instance.Status.TransactionRecovery = nil
+		return &replicas, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if recovery := instance.Status.TransactionRecovery; recovery != nil {
+		ordinal := recovery.Ordinal
+
+		// The Job mounts the persisted storage of the removed pod, so it is only created once the pod is gone
+		pod := &corev1.Pod{}
+		err = r.GetClient().Get(context.TODO(), types.NamespacedName{Name: fmt.Sprintf("%s-%d", statefulSet.Name, ordinal), Namespace: instance.Namespace}, pod)
+		if err == nil {
+			return &ordinal, nil
+		} else if !kerrors.IsNotFound(err) {
+			return nil, err
+		}
+
+		job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: lutils.GetTransactionRecoveryJobName(instance, ordinal), Namespace: instance.Namespace}}
+		err = r.GetClient().Get(context.TODO(), types.NamespacedName{Name: job.Name, Namespace: job.Namespace}, job)
+		if kerrors.IsNotFound(err) {
+			// The pod template of a Job is immutable, so the Job is only customized when it is created
+			err = r.CreateOrUpdate(job, instance, func() error {
+				lutils.CustomizeTransactionRecoveryJob(job, statefulSet, instance, ordinal)
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+			recovery.Job = job.Name
+			return &ordinal, nil
+		} else if err != nil {
+			return nil, err
+		}
+		// The pod is not removed until its transactions are recovered. Deleting the failed Job creates it again.
+		if lutils.IsTransactionRecoveryJobFailed(job) {
+			return nil, lutils.NewReconcileError(lutils.ReconcileReasonTransactionRecoveryFailed,
+				fmt.Errorf("Failed to recover the transactions of pod %s-%d. Check the logs of the pods of Job %s, then delete the Job to retry the recovery",
+					statefulSet.Name, ordinal, job.Name))
+		}
+		if !lutils.IsTransactionRecoveryJobComplete(job) {
+			return &ordinal, nil
+		}
+
+		if err := r.deleteTransactionRecoveryJob(job); err != nil {
+			return nil, err
+		}
+		instance.Status.TransactionRecovery = nil
+		statefulSet.Spec.Replicas = &ordinal
+	}
+
+	if statefulSet.Spec.Replicas != nil && replicas < *statefulSet.Spec.Replicas {
+		ordinal := *statefulSet.Spec.Replicas - 1
+		instance.Status.TransactionRecovery = &webspherelibertyv1.TransactionRecoveryStatus{Ordinal: ordinal}
+		return &ordinal, nil
+	}
+	return &replicas, nil
+}
+
+// deleteTransactionRecoveryJob deletes a transaction recovery Job together with its pods
+func (r *ReconcileWebSphereLiberty) deleteTransactionRecoveryJob(job *batchv1.Job) error {
+	err := r.GetClient().Delete(context.TODO(), job, client.PropagationPolicy(metav1.DeletePropagationBackground))
+	if err != nil && !kerrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// reconcileSessionCache creates the Liberty session cache configuration and, when provisioning is requested, the Infinispan
+// cluster and its credentials. It deletes them when the session cache is disabled.
+func (r *ReconcileWebSphereLiberty) reconcileSessionCache(instance *webspherelibertyv1.WebSphereLibertyApplication) error {
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: lutils.GetSessionCacheConfigMapName(instance), Namespace: instance.Namespace}}
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: lutils.GetSessionCacheGeneratedCredentialsName(instance), Namespace: instance.Namespace}}
+	infinispan := &unstructured.Unstructured{}
+	infinispan.SetGroupVersionKind(lutils.InfinispanGroupVersionKind)
+	infinispan.SetName(lutils.GetSessionCacheInfinispanName(instance))
+	infinispan.SetNamespace(instance.Namespace)
+
+	isInfinispanSupported, err := r.IsGroupVersionSupported(lutils.InfinispanGroupVersionKind.GroupVersion().String(), lutils.InfinispanGroupVersionKind.Kind)
+	if err != nil {
+		return err
+	}
+
+	if !lutils.IsSessionCacheProvisioned(instance) {
+		if isInfinispanSupported {
+			if err := r.DeleteResource(infinispan); err != nil {
+				return err
+			}
+		}
+		if err := r.DeleteResource(secret); err != nil {
+			return err
+		}
+	} else {
+		if !isInfinispanSupported {
+			return lutils.NewReconcileError(lutils.ReconcileReasonAPINotSupported,
+				fmt.Errorf("Failed to provision the session cache. The Infinispan Operator must be installed to use spec.sessionCache.provision"))
+		}
+		err = r.CreateOrUpdate(secret, instance, func() error {
+			return lutils.CustomizeSessionCacheCredentials(secret, instance)
+		})
+		if err != nil {
+			return err
+		}
+		err = r.CreateOrUpdate(infinispan, instance, func() error {
+			return lutils.CustomizeSessionCacheInfinispan(infinispan, instance)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if instance.GetSessionCache() == nil {
+		return r.DeleteResource(cm)
+	}
+	return r.CreateOrUpdate(cm, instance, func() error {
+		lutils.CustomizeSessionCacheConfigMap(cm, instance)
+		return nil
+	})
+}
+
+// reconcileServiceMesh creates the Istio VirtualService, DestinationRule and PeerAuthentication of the application when the
+// service mesh is enabled, and deletes them otherwise
+func (r *ReconcileWebSphereLiberty) reconcileServiceMesh(instance *webspherelibertyv1.WebSphereLibertyApplication) error {
+	vs := &unstructured.Unstructured{}
+	vs.SetGroupVersionKind(lutils.VirtualServiceGroupVersionKind)
+	dr := &unstructured.Unstructured{}
+	dr.SetGroupVersionKind(lutils.DestinationRuleGroupVersionKind)
+	pa := &unstructured.Unstructured{}
+	pa.SetGroupVersionKind(lutils.PeerAuthenticationGroupVersionKind)
+	for _, obj := range []*unstructured.Unstructured{vs, dr, pa} {
+		obj.SetName(instance.Name)
+		obj.SetNamespace(instance.Namespace)
+	}
+
+	isIstioSupported, err := r.IsGroupVersionSupported(lutils.VirtualServiceGroupVersionKind.GroupVersion().String(), lutils.VirtualServiceGroupVersionKind.Kind)
+	if err != nil {
+		return err
+	}
+
+	if instance.GetServiceMesh() == nil {
+		if !isIstioSupported {
+			return nil
+		}
+		return r.DeleteResources([]client.Object{vs, dr, pa})
+	}
+	if !isIstioSupported {
+		return lutils.NewReconcileError(lutils.ReconcileReasonAPINotSupported,
+			fmt.Errorf("Failed to reconcile the service mesh resources. Istio must be installed to use spec.serviceMesh"))
+	}
+
+	err = r.CreateOrUpdate(vs, instance, func() error {
+		return lutils.CustomizeVirtualService(vs, instance)
+	})
+	if err != nil {
+		return err
+	}
+	err = r.CreateOrUpdate(dr, instance, func() error {
+		return lutils.CustomizeDestinationRule(dr, instance)
+	})
+	if err != nil {
+		return err
+	}
+	return r.CreateOrUpdate(pa, instance, func() error {
+		return lutils.CustomizePeerAuthentication(pa, instance)
+	})
+}
+
+// getHTTPRouteGroupVersionKind returns the preferred version of the Gateway API HTTPRoute served by the cluster, if any
+func (r *ReconcileWebSphereLiberty) getHTTPRouteGroupVersionKind() (schema.GroupVersionKind, bool, error) {
+	for _, gvk := range lutils.HTTPRouteGroupVersionKinds {
+		ok, err := r.IsGroupVersionSupported(gvk.GroupVersion().String(), gvk.Kind)
+		if err != nil {
+			return schema.GroupVersionKind{}, false, err
+		}
+		if ok {
+			return gvk, true, nil
+		}
+	}
+	return schema.GroupVersionKind{}, false, nil
+}
+
+// reconcileCertificates creates cert-manager Certificates for the Service and for the Route or Ingress host when no
+// certificate secrets are specified, and points the application to the issued secrets once they are ready
+func (r *ReconcileWebSphereLiberty) reconcileCertificates(instance *webspherelibertyv1.WebSphereLibertyApplication) error {
+	svcCert := &certmanagerv1.Certificate{ObjectMeta: metav1.ObjectMeta{Name: instance.Name + "-svc-tls", Namespace: instance.Namespace}}
+	routeCert := &certmanagerv1.Certificate{ObjectMeta: metav1.ObjectMeta{Name: instance.Name + "-route-tls", Namespace: instance.Namespace}}
+
+	issuerRef := lutils.GetCertManagerIssuerRef()
+	if issuerRef == nil {
+		return r.DeleteResources([]client.Object{svcCert, routeCert})
+	}
+
+	if instance.Spec.Service.CertificateSecretRef == nil {
+		err := r.CreateOrUpdate(svcCert, instance, func() error {
+			lutils.CustomizeServiceCertificate(svcCert, instance, *issuerRef)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if lutils.IsCertificateReady(svcCert) {
+			secretName := svcCert.Spec.SecretName
+			instance.Spec.Service.CertificateSecretRef = &secretName
+		}
+	} else {
+		if err := r.DeleteResource(svcCert); err != nil {
+			return err
+		}
+	}
+
+	hosts := lutils.GetRouteCertificateHosts(instance)
+	// The Gateway terminates TLS for an HTTPRoute with the certificates of its listeners
+	if instance.Spec.Expose != nil && *instance.Spec.Expose && !lutils.IsGatewayRouteEnabled(instance) && len(hosts) > 0 && (instance.Spec.Route == nil || instance.Spec.Route.CertificateSecretRef == nil) {
+		err := r.CreateOrUpdate(routeCert, instance, func() error {
+			lutils.CustomizeRouteCertificate(routeCert, instance, hosts, *issuerRef)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if lutils.IsCertificateReady(routeCert) {
+			if instance.Spec.Route == nil {
+				instance.Spec.Route = &webspherelibertyv1.WebSphereLibertyApplicationRoute{}
+			}
+			secretName := routeCert.Spec.SecretName
+			instance.Spec.Route.CertificateSecretRef = &secretName
+		}
+	} else {
+		if err := r.DeleteResource(routeCert); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (r *ReconcileWebSphereLiberty) deletePVC(reqLogger logr.Logger, pvcName string, pvcNamespace string) {
 	pvc := &corev1.PersistentVolumeClaim{}
 	err := r.GetClient().Get(context.TODO(), types.NamespacedName{Name: pvcName, Namespace: pvcNamespace}, pvc)
@@ -0,0 +1,486 @@
+package controllers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	webspherelibertyv1 "github.com/WASdev/websphere-liberty-operator/api/v1"
+	lutils "github.com/WASdev/websphere-liberty-operator/utils"
+	"github.com/application-stacks/runtime-component-operator/common"
+	oputils "github.com/application-stacks/runtime-component-operator/utils"
+	certmanagerv1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	name      = "app"
+	namespace = "websphereliberty"
+	appImage  = "my-image"
+)
+
+func TestReconcileCertificates(t *testing.T) {
+	logger := zap.New()
+	logf.SetLogger(logger)
+
+	cfg, _ := lutils.ParseOperatorConfig(&corev1.ConfigMap{Data: map[string]string{
+		lutils.OpConfigCertManagerIssuer: "my-issuer",
+		common.OpConfigDefaultHostname:   "apps.example.com",
+	}})
+	lutils.SetOperatorConfig(cfg)
+	defer lutils.SetOperatorConfig(lutils.DefaultOperatorConfig())
+
+	expose := true
+	instance := &webspherelibertyv1.WebSphereLibertyApplication{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: webspherelibertyv1.WebSphereLibertyApplicationSpec{
+			ApplicationImage: appImage,
+			Expose:           &expose,
+		},
+	}
+	instance.Initialize()
+
+	s := runtime.NewScheme()
+	clientgoscheme.AddToScheme(s)
+	webspherelibertyv1.AddToScheme(s)
+	certmanagerv1.AddToScheme(s)
+	cl := fakeclient.NewFakeClientWithScheme(s, instance)
+	r := &ReconcileWebSphereLiberty{
+		ReconcilerBase: oputils.NewReconcilerBase(cl, cl, s, &rest.Config{}, record.NewFakeRecorder(10)),
+		Log:            logger,
+	}
+
+	// Certificates are created, but the application keeps using no certificate until they are issued
+	if err := r.reconcileCertificates(instance); err != nil {
+		t.Fatalf("reconcileCertificates: (%v)", err)
+	}
+	svcCert := &certmanagerv1.Certificate{}
+	if err := cl.Get(context.TODO(), types.NamespacedName{Name: name + "-svc-tls", Namespace: namespace}, svcCert); err != nil {
+		t.Fatalf("Service Certificate was not created: (%v)", err)
+	}
+	routeCert := &certmanagerv1.Certificate{}
+	if err := cl.Get(context.TODO(), types.NamespacedName{Name: name + "-route-tls", Namespace: namespace}, routeCert); err != nil {
+		t.Fatalf("Route Certificate was not created: (%v)", err)
+	}
+	if svcCert.Spec.IssuerRef.Name != "my-issuer" || svcCert.Spec.IssuerRef.Kind != "ClusterIssuer" {
+		t.Fatalf("Unexpected issuer reference (%v)", svcCert.Spec.IssuerRef)
+	}
+	if len(routeCert.Spec.DNSNames) != 1 || routeCert.Spec.DNSNames[0] != name+"-"+namespace+".apps.example.com" {
+		t.Fatalf("Unexpected Route Certificate DNS names (%v)", routeCert.Spec.DNSNames)
+	}
+	if instance.Spec.Service.CertificateSecretRef != nil {
+		t.Fatalf("Service certificate secret set before the Certificate was issued")
+	}
+
+	issueCertificate(t, cl, svcCert)
+	issueCertificate(t, cl, routeCert)
+
+	// Once issued, the secrets are used by the Service and the Route
+	if err := r.reconcileCertificates(instance); err != nil {
+		t.Fatalf("reconcileCertificates: (%v)", err)
+	}
+	if ref := instance.Spec.Service.CertificateSecretRef; ref == nil || *ref != name+"-svc-tls" {
+		t.Fatalf("Service certificate secret was not set to the issued secret (%v)", ref)
+	}
+	if instance.Spec.Route == nil || instance.Spec.Route.CertificateSecretRef == nil || *instance.Spec.Route.CertificateSecretRef != name+"-route-tls" {
+		t.Fatalf("Route certificate secret was not set to the issued secret")
+	}
+	key, cert, caCert, _, err := r.GetRouteTLSValues(instance)
+	if err != nil {
+		t.Fatalf("GetRouteTLSValues: (%v)", err)
+	}
+	if key != "route-key" || cert != "route-cert" || caCert != "ca-cert" {
+		t.Fatalf("Unexpected Route TLS values key: (%s) cert: (%s) caCert: (%s)", key, cert, caCert)
+	}
+
+	// Without an issuer, the Certificates are removed
+	cfg, _ = lutils.ParseOperatorConfig(&corev1.ConfigMap{Data: map[string]string{common.OpConfigDefaultHostname: "apps.example.com"}})
+	lutils.SetOperatorConfig(cfg)
+	if err := r.reconcileCertificates(instance); err != nil {
+		t.Fatalf("reconcileCertificates: (%v)", err)
+	}
+	if err := cl.Get(context.TODO(), types.NamespacedName{Name: name + "-svc-tls", Namespace: namespace}, svcCert); err == nil {
+		t.Fatalf("Service Certificate was not deleted")
+	}
+}
+
+func TestReconcileTransactionRecovery(t *testing.T) {
+	logger := zap.New()
+	logf.SetLogger(logger)
+
+	replicas := int32(1)
+	instance := &webspherelibertyv1.WebSphereLibertyApplication{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: webspherelibertyv1.WebSphereLibertyApplicationSpec{
+			ApplicationImage: appImage,
+			Replicas:         &replicas,
+			StatefulSet: &webspherelibertyv1.WebSphereLibertyApplicationStatefulSet{
+				Storage:             &webspherelibertyv1.WebSphereLibertyApplicationStorage{Size: "1Gi"},
+				TransactionRecovery: &webspherelibertyv1.WebSphereLibertyApplicationTransactionRecovery{},
+			},
+		},
+	}
+	instance.Initialize()
+
+	currentReplicas := int32(3)
+	statefulSet := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas:             &currentReplicas,
+			VolumeClaimTemplates: []corev1.PersistentVolumeClaim{{ObjectMeta: metav1.ObjectMeta{Name: "pvc"}}},
+		},
+	}
+	oputils.CustomizePodSpec(&statefulSet.Spec.Template, instance)
+	lutils.CustomizeTransactionRecovery(statefulSet, instance)
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name + "-2", Namespace: namespace}}
+
+	s := runtime.NewScheme()
+	clientgoscheme.AddToScheme(s)
+	webspherelibertyv1.AddToScheme(s)
+	cl := fakeclient.NewFakeClientWithScheme(s, instance, statefulSet, pod)
+	r := &ReconcileWebSphereLiberty{
+		ReconcilerBase: oputils.NewReconcilerBase(cl, cl, s, &rest.Config{}, record.NewFakeRecorder(10)),
+		Log:            logger,
+	}
+
+	// Scaling down removes the last pod only
+	if got, err := r.reconcileTransactionRecovery(instance); err != nil || *got != 2 {
+		t.Fatalf("Unexpected replicas while scaling down (%v) (%v)", got, err)
+	}
+	if instance.Status.TransactionRecovery == nil || instance.Status.TransactionRecovery.Ordinal != 2 {
+		t.Fatalf("Ordinal 2 is not recovered (%v)", instance.Status.TransactionRecovery)
+	}
+
+	// No recovery Job is created while the pod is running
+	if got, err := r.reconcileTransactionRecovery(instance); err != nil || *got != 2 {
+		t.Fatalf("Unexpected replicas while the pod is running (%v) (%v)", got, err)
+	}
+	job := &batchv1.Job{}
+	jobName := types.NamespacedName{Name: name + "-tx-recovery-2", Namespace: namespace}
+	if err := cl.Get(context.TODO(), jobName, job); err == nil {
+		t.Fatalf("Recovery Job was created while the pod is running")
+	}
+
+	// Once the pod is gone, a Job recovers its transactions with its storage and identity
+	if err := cl.Delete(context.TODO(), pod); err != nil {
+		t.Fatalf("Failed to delete pod: (%v)", err)
+	}
+	if got, err := r.reconcileTransactionRecovery(instance); err != nil || *got != 2 {
+		t.Fatalf("Unexpected replicas while recovering (%v) (%v)", got, err)
+	}
+	if err := cl.Get(context.TODO(), jobName, job); err != nil {
+		t.Fatalf("Recovery Job was not created: (%v)", err)
+	}
+	var claimName, identity string
+	for _, v := range job.Spec.Template.Spec.Volumes {
+		if v.PersistentVolumeClaim != nil {
+			claimName = v.PersistentVolumeClaim.ClaimName
+		}
+	}
+	for _, env := range job.Spec.Template.Spec.Containers[0].Env {
+		if env.Name == "TX_RECOVERY_IDENTITY" {
+			identity = env.Value
+		}
+	}
+	if claimName != "pvc-"+name+"-2" || identity != name+"-2" {
+		t.Fatalf("Unexpected recovery Job claim (%s) identity (%s)", claimName, identity)
+	}
+	if job.Spec.Template.Labels["app.kubernetes.io/instance"] == name {
+		t.Fatalf("Recovery Job pods are selected by the application Service")
+	}
+
+	// A failed recovery keeps the pod from being removed, and deleting the Job retries the recovery
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue}}
+	if err := cl.Status().Update(context.TODO(), job); err != nil {
+		t.Fatalf("Failed to fail Job: (%v)", err)
+	}
+	if _, err := r.reconcileTransactionRecovery(instance); err == nil || !strings.Contains(err.Error(), "delete the Job to retry") {
+		t.Fatalf("Failed recovery Job was not reported (%v)", err)
+	}
+	if instance.Status.TransactionRecovery == nil || instance.Status.TransactionRecovery.Ordinal != 2 {
+		t.Fatalf("Ordinal 2 is not recovered after the Job failed (%v)", instance.Status.TransactionRecovery)
+	}
+	if err := cl.Delete(context.TODO(), job); err != nil {
+		t.Fatalf("Failed to delete Job: (%v)", err)
+	}
+	if got, err := r.reconcileTransactionRecovery(instance); err != nil || *got != 2 {
+		t.Fatalf("Unexpected replicas while retrying the recovery (%v) (%v)", got, err)
+	}
+	job = &batchv1.Job{}
+	if err := cl.Get(context.TODO(), jobName, job); err != nil {
+		t.Fatalf("Recovery Job was not created again: (%v)", err)
+	}
+
+	// Once recovered, the next pod is removed
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+	if err := cl.Status().Update(context.TODO(), job); err != nil {
+		t.Fatalf("Failed to complete Job: (%v)", err)
+	}
+	if got, err := r.reconcileTransactionRecovery(instance); err != nil || *got != 1 {
+		t.Fatalf("Unexpected replicas after recovery (%v) (%v)", got, err)
+	}
+	if instance.Status.TransactionRecovery == nil || instance.Status.TransactionRecovery.Ordinal != 1 {
+		t.Fatalf("Ordinal 1 is not recovered (%v)", instance.Status.TransactionRecovery)
+	}
+	if err := cl.Get(context.TODO(), jobName, job); err == nil {
+		t.Fatalf("Completed recovery Job was not deleted")
+	}
+}
+
+func TestReconcileOperatorConfig(t *testing.T) {
+	logger := zap.New()
+	logf.SetLogger(logger)
+	defer lutils.SetOperatorConfig(lutils.DefaultOperatorConfig())
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: lutils.OperatorConfigMapName, Namespace: namespace},
+		Data:       map[string]string{lutils.OpConfigDefaultPullPolicy: "Always", "unknownKey": "value"},
+	}
+	instance := &webspherelibertyv1.WebSphereLibertyApplication{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+
+	s := runtime.NewScheme()
+	clientgoscheme.AddToScheme(s)
+	webspherelibertyv1.AddToScheme(s)
+	cl := fakeclient.NewFakeClientWithScheme(s, cm, instance)
+	recorder := record.NewFakeRecorder(10)
+	configChanges := make(chan event.GenericEvent, 1)
+	r := &ReconcileOperatorConfig{Client: cl, Recorder: recorder, Log: logger, ConfigChanges: configChanges, configReader: cl}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: cm.Name, Namespace: namespace}}
+
+	// The valid keys are applied, the invalid keys are reported, and the applications are reconciled again
+	if _, err := r.Reconcile(context.TODO(), req); err != nil {
+		t.Fatalf("Reconcile: (%v)", err)
+	}
+	if pp := lutils.GetOperatorConfig().DefaultPullPolicy; pp != corev1.PullAlways {
+		t.Fatalf("Unexpected default pull policy (%v)", pp)
+	}
+	if len(recorder.Events) != 1 {
+		t.Fatalf("Unknown key was not reported, %d events", len(recorder.Events))
+	}
+	select {
+	case e := <-configChanges:
+		if e.Object.GetName() != name {
+			t.Fatalf("Unexpected application reconciled again (%v)", e.Object.GetName())
+		}
+	default:
+		t.Fatalf("The application was not reconciled again")
+	}
+
+	// An unchanged configuration is not applied again, and the ConfigMap is not written back
+	if _, err := r.Reconcile(context.TODO(), req); err != nil {
+		t.Fatalf("Reconcile: (%v)", err)
+	}
+	if len(configChanges) != 0 {
+		t.Fatalf("The application was reconciled again for an unchanged configuration")
+	}
+	if err := cl.Get(context.TODO(), req.NamespacedName, cm); err != nil || len(cm.Data) != 2 {
+		t.Fatalf("The ConfigMap was modified (%v) (%v)", cm.Data, err)
+	}
+
+	// Without the ConfigMap, the defaults are used
+	if err := cl.Delete(context.TODO(), cm); err != nil {
+		t.Fatalf("Failed to delete ConfigMap: (%v)", err)
+	}
+	if _, err := r.Reconcile(context.TODO(), req); err != nil {
+		t.Fatalf("Reconcile: (%v)", err)
+	}
+	if pp := lutils.GetOperatorConfig().DefaultPullPolicy; pp != corev1.PullIfNotPresent {
+		t.Fatalf("Unexpected default pull policy after the ConfigMap was deleted (%v)", pp)
+	}
+}
+
+func TestReconcilePaused(t *testing.T) {
+	logger := zap.New()
+	logf.SetLogger(logger)
+
+	instance := &webspherelibertyv1.WebSphereLibertyApplication{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   namespace,
+			Annotations: map[string]string{lutils.ReconcilePausedAnnotation: "true"},
+		},
+		Spec: webspherelibertyv1.WebSphereLibertyApplicationSpec{ApplicationImage: appImage},
+	}
+
+	s := runtime.NewScheme()
+	clientgoscheme.AddToScheme(s)
+	webspherelibertyv1.AddToScheme(s)
+	cl := fakeclient.NewFakeClientWithScheme(s, instance)
+	r := &ReconcileWebSphereLiberty{
+		ReconcilerBase: oputils.NewReconcilerBase(cl, cl, s, &rest.Config{}, record.NewFakeRecorder(10)),
+		Log:            logger,
+	}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: name, Namespace: namespace}}
+
+	// Nothing is written but the Paused condition
+	if _, err := r.Reconcile(context.TODO(), req); err != nil {
+		t.Fatalf("Reconcile: (%v)", err)
+	}
+	if err := cl.Get(context.TODO(), req.NamespacedName, &appsv1.Deployment{}); err == nil {
+		t.Fatalf("Deployment was created while reconciliation was paused")
+	}
+	if err := cl.Get(context.TODO(), req.NamespacedName, instance); err != nil {
+		t.Fatalf("Get: (%v)", err)
+	}
+	if len(instance.GetFinalizers()) != 0 {
+		t.Fatalf("Finalizer was added while reconciliation was paused (%v)", instance.GetFinalizers())
+	}
+	paused := false
+	for _, c := range instance.Status.Conditions {
+		if c.Type == webspherelibertyv1.StatusConditionTypePaused && c.Status == corev1.ConditionTrue && c.Reason == lutils.StatusReasonReconcilePaused {
+			paused = true
+		}
+	}
+	if !paused {
+		t.Fatalf("Paused condition was not reported (%v)", instance.Status.Conditions)
+	}
+
+	// The status is not updated again while the Paused condition is already reported
+	resourceVersion := instance.ResourceVersion
+	if _, err := r.Reconcile(context.TODO(), req); err != nil {
+		t.Fatalf("Reconcile: (%v)", err)
+	}
+	if err := cl.Get(context.TODO(), req.NamespacedName, instance); err != nil {
+		t.Fatalf("Get: (%v)", err)
+	}
+	if instance.ResourceVersion != resourceVersion {
+		t.Fatalf("Status was updated again while reconciliation was paused")
+	}
+}
+
+func TestReconcilePausedDeletion(t *testing.T) {
+	logger := zap.New()
+	logf.SetLogger(logger)
+
+	now := metav1.Now()
+	instance := &webspherelibertyv1.WebSphereLibertyApplication{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         namespace,
+			Annotations:       map[string]string{lutils.ReconcilePausedAnnotation: "true"},
+			Finalizers:        []string{applicationFinalizer},
+			DeletionTimestamp: &now,
+		},
+		Spec: webspherelibertyv1.WebSphereLibertyApplicationSpec{ApplicationImage: appImage},
+	}
+
+	s := runtime.NewScheme()
+	clientgoscheme.AddToScheme(s)
+	webspherelibertyv1.AddToScheme(s)
+	cl := fakeclient.NewFakeClientWithScheme(s, instance)
+	r := &ReconcileWebSphereLiberty{
+		ReconcilerBase: oputils.NewReconcilerBase(cl, cl, s, &rest.Config{}, record.NewFakeRecorder(10)),
+		Log:            logger,
+	}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: name, Namespace: namespace}}
+
+	// The finalizer is removed even though reconciliation is paused
+	if _, err := r.Reconcile(context.TODO(), req); err != nil {
+		t.Fatalf("Reconcile: (%v)", err)
+	}
+	if err := cl.Get(context.TODO(), req.NamespacedName, instance); err == nil && lutils.Contains(instance.GetFinalizers(), applicationFinalizer) {
+		t.Fatalf("Finalizer was not removed from the paused application being deleted")
+	}
+}
+
+func TestReconcileDumpMetrics(t *testing.T) {
+	logger := zap.New()
+	logf.SetLogger(logger)
+
+	dump := &webspherelibertyv1.WebSphereLibertyDump{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec:       webspherelibertyv1.WebSphereLibertyDumpSpec{PodName: name + "-0"},
+	}
+
+	s := runtime.NewScheme()
+	clientgoscheme.AddToScheme(s)
+	webspherelibertyv1.AddToScheme(s)
+	cl := fakeclient.NewFakeClientWithScheme(s, dump)
+	r := &ReconcileWebSphereLibertyDump{Client: cl, Scheme: s, Recorder: record.NewFakeRecorder(10), Log: logger}
+
+	successes := gatherCounter(t, "websphere_liberty_operator_reconcile_total", map[string]string{"kind": operationKindDump, "result": "success"})
+	failures := gatherCounter(t, "websphere_liberty_operator_reconcile_errors_total", map[string]string{"kind": operationKindDump, "reason": lutils.ReconcileReasonPodNotFound})
+
+	// A reconcile that returns before the dump is run is counted
+	if _, err := r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "deleted", Namespace: namespace}}); err != nil {
+		t.Fatalf("Reconcile: (%v)", err)
+	}
+	// A dump of a missing pod is counted by its reason
+	if _, err := r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Name: name, Namespace: namespace}}); err != nil {
+		t.Fatalf("Reconcile: (%v)", err)
+	}
+
+	if got := gatherCounter(t, "websphere_liberty_operator_reconcile_total", map[string]string{"kind": operationKindDump, "result": "success"}); got != successes+1 {
+		t.Fatalf("Reconcile of a deleted dump was not counted (%v)", got)
+	}
+	if got := gatherCounter(t, "websphere_liberty_operator_reconcile_errors_total", map[string]string{"kind": operationKindDump, "reason": lutils.ReconcileReasonPodNotFound}); got != failures+1 {
+		t.Fatalf("Dump of a missing pod was not counted as %s (%v)", lutils.ReconcileReasonPodNotFound, got)
+	}
+}
+
+// gatherCounter returns the value of the counter with the labels in the metrics registry of the manager
+func gatherCounter(t *testing.T, metricName string, labels map[string]string) float64 {
+	families, err := metrics.Registry.Gather()
+	if err != nil {
+		t.Fatalf("Failed to gather metrics: (%v)", err)
+	}
+	for _, family := range families {
+		if family.GetName() != metricName {
+			continue
+		}
+		for _, m := range family.GetMetric() {
+			matched := 0
+			for _, l := range m.GetLabel() {
+				if labels[l.GetName()] == l.GetValue() {
+					matched++
+				}
+			}
+			if matched == len(labels) {
+				return m.GetCounter().GetValue()
+			}
+		}
+	}
+	return 0
+}
+
+// issueCertificate acts as the cert-manager controller: it writes the secret of the Certificate and marks it ready
+func issueCertificate(t *testing.T, cl client.Client, cert *certmanagerv1.Certificate) {
+	prefix := "svc"
+	if cert.Name == name+"-route-tls" {
+		prefix = "route"
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: cert.Spec.SecretName, Namespace: cert.Namespace},
+		Type:       corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			"tls.key": []byte(prefix + "-key"),
+			"tls.crt": []byte(prefix + "-cert"),
+			"ca.crt":  []byte("ca-cert"),
+		},
+	}
+	if err := cl.Create(context.TODO(), secret); err != nil {
+		t.Fatalf("Failed to create secret for Certificate %s: (%v)", cert.Name, err)
+	}
+	cert.Status.Conditions = []certmanagerv1.CertificateCondition{
+		{Type: certmanagerv1.CertificateConditionReady, Status: cmmeta.ConditionTrue},
+	}
+	if err := cl.Status().Update(context.TODO(), cert); err != nil {
+		t.Fatalf("Failed to mark Certificate %s as ready: (%v)", cert.Name, err)
+	}
+}
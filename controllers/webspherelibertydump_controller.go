@@ -2,6 +2,7 @@ package controllers
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"time"
 
@@ -19,11 +20,15 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
+// operationKindDump labels the metrics of the server dumps
+const operationKindDump = "WebSphereLibertyDump"
+
 // ReconcileWebSphereLibertyDump reconciles a WebSphereLibertyDump object
 type ReconcileWebSphereLibertyDump struct {
 	// This client, initialized using mgr.Client() above, is a split client
@@ -33,6 +38,8 @@ type ReconcileWebSphereLibertyDump struct {
 	Recorder   record.EventRecorder
 	RestConfig *rest.Config
 	Log        logr.Logger
+	// MaxConcurrentReconciles is the maximum number of server dumps run concurrently. Defaults to 1.
+	MaxConcurrentReconciles int
 }
 
 // +kubebuilder:rbac:groups=liberty.websphere.ibm.com,resources=webspherelibertydumps;webspherelibertydumps/status;webspherelibertydumps/finalizers,verbs=*,namespace=websphere-liberty-operator
@@ -56,19 +63,32 @@ func (r *ReconcileWebSphereLibertyDump) Reconcile(ctx context.Context, request c
 			// Request object not found, could have been deleted after reconcile request.
 			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
 			// Return and don't requeue
+			utils.RecordReconcile(operationKindDump, nil)
 			return reconcile.Result{}, nil
 		}
 		// Error reading the object - requeue the request.
+		utils.RecordReconcile(operationKindDump, err)
+		return reconcile.Result{}, err
+	}
+
+	// While reconciliation is paused, only the Paused condition is reported
+	if paused, err := utils.ReconcileOperationPaused(r.Client, instance, &instance.Status.Conditions); err != nil || paused {
+		if paused {
+			reqLogger.Info("Reconciliation of WebSphereLibertyDump is paused")
+		}
+		utils.RecordReconcile(operationKindDump, err)
 		return reconcile.Result{}, err
 	}
 
 	//do not reconcile if the dump already started
 	oc := webspherelibertyv1.GetOperationCondtion(instance.Status.Conditions, webspherelibertyv1.OperationStatusConditionTypeStarted)
 	if oc != nil && oc.Status == corev1.ConditionTrue {
+		utils.RecordReconcile(operationKindDump, nil)
 		return reconcile.Result{}, err
 	}
 
 	//check if Pod exists and running
+	start := time.Now()
 	pod := &corev1.Pod{}
 
 	err = r.Client.Get(context.TODO(), types.NamespacedName{Name: instance.Spec.PodName, Namespace: request.Namespace}, pod)
@@ -85,6 +105,12 @@ func (r *ReconcileWebSphereLibertyDump) Reconcile(ctx context.Context, request c
 		}
 		instance.Status.Conditions = webspherelibertyv1.SetOperationCondtion(instance.Status.Conditions, c)
 		r.Client.Status().Update(context.TODO(), instance)
+		if err == nil {
+			err = utils.NewReconcileError(utils.ReconcileReasonPodNotRunning, fmt.Errorf("pod %s is in phase %s", instance.Spec.PodName, pod.Status.Phase))
+		} else if errors.IsNotFound(err) {
+			err = utils.NewReconcileError(utils.ReconcileReasonPodNotFound, err)
+		}
+		utils.RecordOperation(operationKindDump, start, err)
 		return reconcile.Result{}, nil
 	}
 
@@ -120,6 +146,7 @@ func (r *ReconcileWebSphereLibertyDump) Reconcile(ctx context.Context, request c
 		}
 		instance.Status.Conditions = webspherelibertyv1.SetOperationCondtion(instance.Status.Conditions, c)
 		r.Client.Status().Update(context.TODO(), instance)
+		utils.RecordOperation(operationKindDump, start, utils.NewReconcileError(utils.ReconcileReasonExecFailed, err))
 		return reconcile.Result{}, nil
 
 	}
@@ -132,6 +159,7 @@ func (r *ReconcileWebSphereLibertyDump) Reconcile(ctx context.Context, request c
 	instance.Status.Conditions = webspherelibertyv1.SetOperationCondtion(instance.Status.Conditions, c)
 	instance.Status.DumpFile = dumpFileName
 	r.Client.Status().Update(context.TODO(), instance)
+	utils.RecordOperation(operationKindDump, start, nil)
 	return reconcile.Result{}, nil
 }
 
@@ -153,8 +181,9 @@ func (r *ReconcileWebSphereLibertyDump) SetupWithManager(mgr ctrl.Manager) error
 
 	pred := predicate.Funcs{
 		UpdateFunc: func(e event.UpdateEvent) bool {
-			// Ignore updates to CR status in which case metadata.Generation does not change
-			return e.ObjectOld.GetGeneration() != e.ObjectNew.GetGeneration() && (isClusterWide || watchNamespacesMap[e.ObjectOld.GetNamespace()])
+			// Ignore updates to CR status in which case metadata.Generation does not change, unless reconciliation is paused or resumed
+			return (e.ObjectOld.GetGeneration() != e.ObjectNew.GetGeneration() || utils.IsReconcilePausedChanged(e.ObjectOld, e.ObjectNew)) &&
+				(isClusterWide || watchNamespacesMap[e.ObjectOld.GetNamespace()])
 		},
 		CreateFunc: func(e event.CreateEvent) bool {
 			return isClusterWide || watchNamespacesMap[e.Object.GetNamespace()]
@@ -166,6 +195,8 @@ func (r *ReconcileWebSphereLibertyDump) SetupWithManager(mgr ctrl.Manager) error
 			return isClusterWide || watchNamespacesMap[e.Object.GetNamespace()]
 		},
 	}
-	return ctrl.NewControllerManagedBy(mgr).For(&webspherelibertyv1.WebSphereLibertyDump{}, builder.WithPredicates(pred)).Complete(r)
+	return ctrl.NewControllerManagedBy(mgr).For(&webspherelibertyv1.WebSphereLibertyDump{}, builder.WithPredicates(pred)).
+		WithOptions(controller.Options{MaxConcurrentReconciles: r.MaxConcurrentReconciles}).
+		Complete(r)
 
 }
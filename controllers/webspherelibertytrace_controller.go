@@ -22,6 +22,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -36,12 +37,17 @@ type ReconcileWebSphereLibertyTrace struct {
 	Recorder   record.EventRecorder
 	RestConfig *rest.Config
 	Log        logr.Logger
+	// MaxConcurrentReconciles is the maximum number of trace operations run concurrently. Defaults to 1.
+	MaxConcurrentReconciles int
 }
 
 const traceFinalizer = "finalizer.webspherelibertytraces.liberty.websphere.ibm.com"
 const traceConfigFile = "/config/configDropins/overrides/add_trace.xml"
 const serviceabilityDir = "/serviceability"
 
+// operationKindTrace labels the metrics of the traces
+const operationKindTrace = "WebSphereLibertyTrace"
+
 // +kubebuilder:rbac:groups=liberty.websphere.ibm.com,resources=webspherelibertytraces;webspherelibertytraces/status;webspherelibertytraces/finalizers,verbs=*,namespace=websphere-liberty-operator
 // +kubebuilder:rbac:groups=core,resources=pods;pods/exec,verbs=*,namespace=websphere-liberty-operator
 
@@ -64,10 +70,12 @@ func (r *ReconcileWebSphereLibertyTrace) Reconcile(ctx context.Context, request
 			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
 			// Return and don't requeue
 			reqLogger.Info("Not found. Return and don't requeue")
+			utils.RecordReconcile(operationKindTrace, nil)
 			return reconcile.Result{}, nil
 		}
 		reqLogger.Info("Error reading the object - requeue the request.")
 		// Error reading the object - requeue the request.
+		utils.RecordReconcile(operationKindTrace, err)
 		return reconcile.Result{}, err
 	}
 
@@ -89,6 +97,7 @@ func (r *ReconcileWebSphereLibertyTrace) Reconcile(ctx context.Context, request
 			// Run finalization logic for traceFinalizer. If the finalization logic fails, don't remove the
 			// finalizer so that we can retry during the next reconciliation.
 			if err := r.finalizeWebSphereLibertyTrace(reqLogger, instance, prevTraceEnabled, prevPodName, podNamespace); err != nil {
+				utils.RecordReconcile(operationKindTrace, err)
 				return reconcile.Result{}, err
 			}
 
@@ -96,20 +105,33 @@ func (r *ReconcileWebSphereLibertyTrace) Reconcile(ctx context.Context, request
 			instance.SetFinalizers(lutils.Remove(instance.GetFinalizers(), traceFinalizer))
 			err := r.Client.Update(context.TODO(), instance)
 			if err != nil {
+				utils.RecordReconcile(operationKindTrace, err)
 				return reconcile.Result{}, err
 			}
 		}
+		utils.RecordReconcile(operationKindTrace, nil)
 		return reconcile.Result{}, nil
 	}
 
+	// While reconciliation is paused, only the Paused condition is reported. Deletion is not paused, so that the finalizer does not block it.
+	if paused, err := utils.ReconcileOperationPaused(r.Client, instance, &instance.Status.Conditions); err != nil || paused {
+		if paused {
+			reqLogger.Info("Reconciliation of WebSphereLibertyTrace is paused")
+		}
+		utils.RecordReconcile(operationKindTrace, err)
+		return reconcile.Result{}, err
+	}
+
 	// Add finalizer for this CR
 	if !lutils.Contains(instance.GetFinalizers(), traceFinalizer) {
 		if err := r.addFinalizer(reqLogger, instance); err != nil {
+			utils.RecordReconcile(operationKindTrace, err)
 			return reconcile.Result{}, err
 		}
 	}
 
 	//If pod name changed, then stop tracing on previous pod (if trace was enabled on it)
+	start := time.Now()
 	if podChanged && (prevTraceEnabled == corev1.ConditionTrue) {
 		r.disableTraceOnPrevPod(reqLogger, prevPodName, podNamespace)
 	}
@@ -118,6 +140,7 @@ func (r *ReconcileWebSphereLibertyTrace) Reconcile(ctx context.Context, request
 	if err != nil && errors.IsNotFound(err) {
 		//Pod is not found. Return and don't requeue
 		reqLogger.Error(err, "Pod "+podName+" was not found in namespace "+podNamespace)
+		utils.RecordOperation(operationKindTrace, start, utils.NewReconcileError(utils.ReconcileReasonPodNotFound, err))
 		return r.UpdateStatus(err, webspherelibertyv1.OperationStatusConditionTypeEnabled, *instance, corev1.ConditionFalse, podName, podChanged)
 	}
 
@@ -127,11 +150,13 @@ func (r *ReconcileWebSphereLibertyTrace) Reconcile(ctx context.Context, request
 			_, err = utils.ExecuteCommandInContainer(r.RestConfig, podName, podNamespace, "app", []string{"/bin/sh", "-c", "rm -f " + traceConfigFile})
 			if err != nil {
 				reqLogger.Error(err, "Encountered error while disabling trace for pod "+podName+" in namespace "+podNamespace)
+				utils.RecordOperation(operationKindTrace, start, utils.NewReconcileError(utils.ReconcileReasonExecFailed, err))
 				return r.UpdateStatus(err, webspherelibertyv1.OperationStatusConditionTypeEnabled, *instance, corev1.ConditionTrue, podName, podChanged)
 			}
 			reqLogger.Info("Disabled trace for pod " + podName + " in namespace " + podNamespace)
 		}
 		r.UpdateStatus(nil, webspherelibertyv1.OperationStatusConditionTypeEnabled, *instance, corev1.ConditionFalse, podName, podChanged)
+		utils.RecordOperation(operationKindTrace, start, nil)
 	} else {
 		traceOutputDir := serviceabilityDir + "/" + podNamespace + "/" + podName
 		traceConfig := "<server><logging traceSpecification=\"" + instance.Spec.TraceSpecification + "\" logDirectory=\"" + traceOutputDir + "\""
@@ -146,6 +171,7 @@ func (r *ReconcileWebSphereLibertyTrace) Reconcile(ctx context.Context, request
 		_, err = utils.ExecuteCommandInContainer(r.RestConfig, podName, podNamespace, "app", []string{"/bin/sh", "-c", "mkdir -p " + traceOutputDir + " && echo '" + traceConfig + "' > " + traceConfigFile})
 		if err != nil {
 			reqLogger.Error(err, "Encountered error while setting up trace for pod "+podName+" in namespace "+podNamespace)
+			utils.RecordOperation(operationKindTrace, start, utils.NewReconcileError(utils.ReconcileReasonExecFailed, err))
 			return r.UpdateStatus(err, webspherelibertyv1.OperationStatusConditionTypeEnabled, *instance, corev1.ConditionFalse, podName, podChanged)
 		}
 
@@ -155,6 +181,7 @@ func (r *ReconcileWebSphereLibertyTrace) Reconcile(ctx context.Context, request
 			reqLogger.Info("Updated trace for pod " + podName + " in namespace " + podNamespace)
 		}
 		r.UpdateStatus(nil, webspherelibertyv1.OperationStatusConditionTypeEnabled, *instance, corev1.ConditionTrue, podName, podChanged)
+		utils.RecordOperation(operationKindTrace, start, nil)
 	}
 
 	return reconcile.Result{}, nil
@@ -259,8 +286,9 @@ func (r *ReconcileWebSphereLibertyTrace) SetupWithManager(mgr ctrl.Manager) erro
 
 	pred := predicate.Funcs{
 		UpdateFunc: func(e event.UpdateEvent) bool {
-			// Ignore updates to CR status in which case metadata.Generation does not change
-			return e.ObjectOld.GetGeneration() != e.ObjectNew.GetGeneration() && (isClusterWide || watchNamespacesMap[e.ObjectOld.GetNamespace()])
+			// Ignore updates to CR status in which case metadata.Generation does not change, unless reconciliation is paused or resumed
+			return (e.ObjectOld.GetGeneration() != e.ObjectNew.GetGeneration() || utils.IsReconcilePausedChanged(e.ObjectOld, e.ObjectNew)) &&
+				(isClusterWide || watchNamespacesMap[e.ObjectOld.GetNamespace()])
 		},
 		CreateFunc: func(e event.CreateEvent) bool {
 			return isClusterWide || watchNamespacesMap[e.Object.GetNamespace()]
@@ -272,5 +300,7 @@ func (r *ReconcileWebSphereLibertyTrace) SetupWithManager(mgr ctrl.Manager) erro
 			return isClusterWide || watchNamespacesMap[e.Object.GetNamespace()]
 		},
 	}
-	return ctrl.NewControllerManagedBy(mgr).For(&webspherelibertyv1.WebSphereLibertyTrace{}, builder.WithPredicates(pred)).Complete(r)
+	return ctrl.NewControllerManagedBy(mgr).For(&webspherelibertyv1.WebSphereLibertyTrace{}, builder.WithPredicates(pred)).
+		WithOptions(controller.Options{MaxConcurrentReconciles: r.MaxConcurrentReconciles}).
+		Complete(r)
 }
@@ -6,14 +6,17 @@ require (
 	github.com/application-stacks/runtime-component-operator v0.7.2-0.20211103162814-e640340f7d4c
 	github.com/coreos/prometheus-operator v0.41.1
 	github.com/go-logr/logr v0.3.0
+	github.com/jetstack/cert-manager v1.1.0
 	github.com/openshift/api v0.0.0-20201019163320-c6a5ec25f267
 	github.com/openshift/library-go v0.0.0-20201026125231-a28d3d1bad23
 	github.com/pkg/errors v0.9.1
+	github.com/prometheus/client_golang v1.7.1
 	k8s.io/api v0.19.2
 	k8s.io/apimachinery v0.19.2
 	k8s.io/client-go v12.0.0+incompatible
 	knative.dev/serving v0.18.1
 	sigs.k8s.io/controller-runtime v0.7.2
+	sigs.k8s.io/yaml v1.2.0
 )
 
 // Pinned to kubernetes-1.16.2
@@ -17,22 +17,34 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
+	"net"
+	"net/http"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	webspherelibertyv1 "github.com/WASdev/websphere-liberty-operator/api/v1"
+	webspherelibertyv1beta2 "github.com/WASdev/websphere-liberty-operator/api/v1beta2"
 	"github.com/WASdev/websphere-liberty-operator/controllers"
 
 	"github.com/application-stacks/runtime-component-operator/utils"
 	prometheusv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
+	certmanagerv1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
 	imagev1 "github.com/openshift/api/image/v1"
 	routev1 "github.com/openshift/api/route/v1"
 	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
@@ -49,6 +61,8 @@ func init() {
 
 	utilruntime.Must(webspherelibertyv1.AddToScheme(scheme))
 
+	utilruntime.Must(webspherelibertyv1beta2.AddToScheme(scheme))
+
 	utilruntime.Must(routev1.AddToScheme(scheme))
 
 	utilruntime.Must(prometheusv1.AddToScheme(scheme))
@@ -56,19 +70,32 @@ func init() {
 	utilruntime.Must(imagev1.AddToScheme(scheme))
 
 	utilruntime.Must(servingv1.AddToScheme(scheme))
+
+	utilruntime.Must(certmanagerv1.AddToScheme(scheme))
 	// +kubebuilder:scaffold:scheme
 }
 
 func main() {
-	//var metricsAddr string
+	var metricsAddr string
+	var probeAddr string
 	var enableLeaderElection bool
-	//flag.StringVar(&metricsAddr, "metrics-addr", ":8080", "The address the metric endpoint binds to.")
+	var leaseDuration time.Duration
+	var maxConcurrentReconciles int
+	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metric endpoint binds to. "+
+		"The metrics endpoint is disabled by default.")
+	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "enable-leader-election", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.DurationVar(&leaseDuration, "leader-elect-lease-duration", 15*time.Second,
+		"The duration that non-leader candidates will wait before forcing to acquire leadership.")
+	flag.IntVar(&maxConcurrentReconciles, "max-concurrent-reconciles", 1,
+		"The maximum number of concurrent reconciles of each controller.")
+	opts := zap.Options{}
+	opts.BindFlags(flag.CommandLine)
 	flag.Parse()
 
-	ctrl.SetLogger(zap.New(zap.UseDevMode(true)))
+	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
 	watchNamespace, err := getWatchNamespace()
 	if err != nil {
@@ -76,48 +103,110 @@ func main() {
 			"the manager will watch and manage resources in all Namespaces")
 	}
 
-	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
-		Scheme:             scheme,
-		MetricsBindAddress: "0",
-		Port:               9443,
-		LeaderElection:     enableLeaderElection,
-		LeaderElectionID:   "7111f50b.websphere.ibm.com",
-		Namespace:          watchNamespace,
-	})
+	options := ctrl.Options{
+		Scheme:                 scheme,
+		MetricsBindAddress:     metricsAddr,
+		HealthProbeBindAddress: probeAddr,
+		Port:                   9443,
+		LeaderElection:         enableLeaderElection,
+		LeaderElectionID:       "7111f50b.websphere.ibm.com",
+		LeaseDuration:          &leaseDuration,
+	}
+	// The cache of the manager only lists and watches the namespaces the operator watches
+	if watchNamespaces := parseWatchNamespaces(watchNamespace); len(watchNamespaces) > 1 {
+		setupLog.Info("the manager will watch and manage resources in multiple Namespaces", "namespaces", watchNamespaces)
+		options.NewCache = cache.MultiNamespacedCacheBuilder(watchNamespaces)
+	} else if len(watchNamespaces) == 1 {
+		options.Namespace = watchNamespaces[0]
+	}
+
+	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), options)
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
 		os.Exit(1)
 	}
 
+	configChanges := make(chan event.GenericEvent)
+	operatorConfigReconciler := &controllers.ReconcileOperatorConfig{
+		Log:           ctrl.Log.WithName("controllers").WithName("OperatorConfig"),
+		Client:        mgr.GetClient(),
+		Recorder:      mgr.GetEventRecorderFor("websphere-liberty-operator"),
+		ConfigChanges: configChanges,
+	}
+	if err = operatorConfigReconciler.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "OperatorConfig")
+		os.Exit(1)
+	}
+	// The caches of the manager are not started yet, so the configuration is read from the API server
+	if err = operatorConfigReconciler.LoadOperatorConfig(context.TODO(), mgr.GetAPIReader()); err != nil {
+		setupLog.Error(err, "unable to load the operator configuration")
+		os.Exit(1)
+	}
 	if err = (&controllers.ReconcileWebSphereLiberty{
-		ReconcilerBase: utils.NewReconcilerBase(mgr.GetAPIReader(), mgr.GetClient(), mgr.GetScheme(), mgr.GetConfig(), mgr.GetEventRecorderFor("websphere-liberty-operator")),
-		Log:            ctrl.Log.WithName("controllers").WithName("WebSphereLibertyApplication"),
+		ReconcilerBase:          utils.NewReconcilerBase(mgr.GetAPIReader(), mgr.GetClient(), mgr.GetScheme(), mgr.GetConfig(), mgr.GetEventRecorderFor("websphere-liberty-operator")),
+		Log:                     ctrl.Log.WithName("controllers").WithName("WebSphereLibertyApplication"),
+		ConfigChanges:           configChanges,
+		MaxConcurrentReconciles: maxConcurrentReconciles,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "WebSphereLibertyApplication")
 		os.Exit(1)
 	}
 	if err = (&controllers.ReconcileWebSphereLibertyDump{
-		Log:        ctrl.Log.WithName("controllers").WithName("WebSphereLibertyDump"),
-		Client:     mgr.GetClient(),
-		Scheme:     mgr.GetScheme(),
-		RestConfig: mgr.GetConfig(),
-		Recorder:   mgr.GetEventRecorderFor("websphere-liberty-operator"),
+		Log:                     ctrl.Log.WithName("controllers").WithName("WebSphereLibertyDump"),
+		Client:                  mgr.GetClient(),
+		Scheme:                  mgr.GetScheme(),
+		RestConfig:              mgr.GetConfig(),
+		Recorder:                mgr.GetEventRecorderFor("websphere-liberty-operator"),
+		MaxConcurrentReconciles: maxConcurrentReconciles,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "WebSphereLibertyDump")
 		os.Exit(1)
 	}
 	if err = (&controllers.ReconcileWebSphereLibertyTrace{
-		Log:        ctrl.Log.WithName("controllers").WithName("WebSphereLibertyTrace"),
-		Client:     mgr.GetClient(),
-		Scheme:     mgr.GetScheme(),
-		RestConfig: mgr.GetConfig(),
-		Recorder:   mgr.GetEventRecorderFor("websphere-liberty-operator"),
+		Log:                     ctrl.Log.WithName("controllers").WithName("WebSphereLibertyTrace"),
+		Client:                  mgr.GetClient(),
+		Scheme:                  mgr.GetScheme(),
+		RestConfig:              mgr.GetConfig(),
+		Recorder:                mgr.GetEventRecorderFor("websphere-liberty-operator"),
+		MaxConcurrentReconciles: maxConcurrentReconciles,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "WebSphereLibertyTrace")
 		os.Exit(1)
 	}
+	// The serving certificates of the webhooks are issued by cert-manager when installed with kustomize, and by OLM from the
+	// webhookdefinitions of the bundle. Set ENABLE_WEBHOOKS to false to run the manager without them, e.g. locally.
+	enableWebhooks := os.Getenv("ENABLE_WEBHOOKS") != "false"
+	if enableWebhooks {
+		if err = (&webspherelibertyv1.WebSphereLibertyApplication{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "WebSphereLibertyApplication")
+			os.Exit(1)
+		}
+		if err = (&webspherelibertyv1.WebSphereLibertyDump{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "WebSphereLibertyDump")
+			os.Exit(1)
+		}
+		if err = (&webspherelibertyv1.WebSphereLibertyTrace{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "WebSphereLibertyTrace")
+			os.Exit(1)
+		}
+	}
 	// +kubebuilder:scaffold:builder
 
+	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
+		setupLog.Error(err, "unable to set up health check")
+		os.Exit(1)
+	}
+	if err := mgr.AddReadyzCheck("cache", cacheSyncedChecker(mgr.GetCache())); err != nil {
+		setupLog.Error(err, "unable to set up ready check")
+		os.Exit(1)
+	}
+	if enableWebhooks {
+		if err := mgr.AddReadyzCheck("webhook", webhookServerChecker(mgr.GetWebhookServer())); err != nil {
+			setupLog.Error(err, "unable to set up ready check")
+			os.Exit(1)
+		}
+	}
+
 	setupLog.Info("starting manager")
 	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
 		setupLog.Error(err, "problem running manager")
@@ -125,7 +214,7 @@ func main() {
 	}
 }
 
-// getWatchNamespace returns the Namespace the operator should be watching for changes
+// getWatchNamespace returns the Namespace, or the comma-separated list of Namespaces, the operator should be watching for changes
 func getWatchNamespace() (string, error) {
 	// WatchNamespaceEnvVar is the constant for env variable WATCH_NAMESPACE
 	// which specifies the Namespace to watch.
@@ -138,3 +227,43 @@ func getWatchNamespace() (string, error) {
 	}
 	return ns, nil
 }
+
+// parseWatchNamespaces returns the distinct Namespaces of a comma-separated list. An empty list means the operator is running
+// with cluster scope.
+func parseWatchNamespaces(watchNamespace string) []string {
+	var namespaces []string
+	seen := map[string]bool{}
+	for _, ns := range strings.Split(watchNamespace, ",") {
+		ns = strings.TrimSpace(ns)
+		if ns != "" && !seen[ns] {
+			seen[ns] = true
+			namespaces = append(namespaces, ns)
+		}
+	}
+	return namespaces
+}
+
+// cacheSyncedChecker reports the manager as ready once its informer caches are synced
+func cacheSyncedChecker(c cache.Cache) healthz.Checker {
+	return func(req *http.Request) error {
+		if !c.WaitForCacheSync(req.Context()) {
+			return fmt.Errorf("informer caches are not synced")
+		}
+		return nil
+	}
+}
+
+// webhookServerChecker reports the manager as ready once the webhook server accepts connections
+func webhookServerChecker(server *webhook.Server) healthz.Checker {
+	return func(req *http.Request) error {
+		host := server.Host
+		if host == "" {
+			host = "localhost"
+		}
+		conn, err := net.DialTimeout("tcp", net.JoinHostPort(host, strconv.Itoa(server.Port)), time.Second)
+		if err != nil {
+			return fmt.Errorf("webhook server is not serving: %v", err)
+		}
+		return conn.Close()
+	}
+}
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseWatchNamespaces(t *testing.T) {
+	tests := []struct {
+		watchNamespace string
+		expected       []string
+	}{
+		{"", nil},
+		{" , ", nil},
+		{"ns1", []string{"ns1"}},
+		{"ns1,", []string{"ns1"}},
+		{" ns1 , ns2 ", []string{"ns1", "ns2"}},
+		{"ns1,ns2,ns1", []string{"ns1", "ns2"}},
+	}
+	for _, test := range tests {
+		if actual := parseWatchNamespaces(test.watchNamespace); !reflect.DeepEqual(test.expected, actual) {
+			t.Errorf("parseWatchNamespaces(%q): expected %v, got %v", test.watchNamespace, test.expected, actual)
+		}
+	}
+}
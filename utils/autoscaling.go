@@ -0,0 +1,86 @@
+package utils
+
+import (
+	webspherelibertyv1 "github.com/WASdev/websphere-liberty-operator/api/v1"
+	rcoutils "github.com/application-stacks/runtime-component-operator/utils"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Annotations that carry the fields of the autoscaling/v2beta2 API in an autoscaling/v1 HorizontalPodAutoscaler
+const (
+	hpaMetricsAnnotation  = "autoscaling.alpha.kubernetes.io/metrics"
+	hpaBehaviorAnnotation = "autoscaling.alpha.kubernetes.io/behavior"
+)
+
+// IsAutoscalingV2beta2Needed returns true if the autoscaler targets more than CPU utilization, which requires the
+// autoscaling/v2beta2 API. Kubernetes 1.26 and later do not serve it.
+func IsAutoscalingV2beta2Needed(la *webspherelibertyv1.WebSphereLibertyApplication) bool {
+	autoscaling := la.Spec.Autoscaling
+	return autoscaling != nil && (autoscaling.TargetMemoryUtilizationPercentage != nil || len(autoscaling.Metrics) > 0 || autoscaling.Behavior != nil)
+}
+
+// CustomizeCPUHPA configures an autoscaling/v1 HorizontalPodAutoscaler that scales the Deployment or the StatefulSet of the
+// application on its CPU utilization. The fields set through the autoscaling/v2beta2 API are removed.
+func CustomizeCPUHPA(hpa *autoscalingv1.HorizontalPodAutoscaler, la *webspherelibertyv1.WebSphereLibertyApplication) {
+	autoscaling := la.Spec.Autoscaling
+	hpa.Labels = la.GetLabels()
+	hpa.Annotations = rcoutils.MergeMaps(hpa.Annotations, la.GetAnnotations())
+	delete(hpa.Annotations, hpaMetricsAnnotation)
+	delete(hpa.Annotations, hpaBehaviorAnnotation)
+
+	hpa.Spec.MaxReplicas = autoscaling.GetMaxReplicas()
+	hpa.Spec.MinReplicas = autoscaling.GetMinReplicas()
+	hpa.Spec.ScaleTargetRef = autoscalingv1.CrossVersionObjectReference{
+		APIVersion: "apps/v1",
+		Kind:       "Deployment",
+		Name:       la.GetName(),
+	}
+	if la.Spec.StatefulSet != nil {
+		hpa.Spec.ScaleTargetRef.Kind = "StatefulSet"
+	}
+	hpa.Spec.TargetCPUUtilizationPercentage = autoscaling.TargetCPUUtilizationPercentage
+}
+
+// CustomizeHPA configures a HorizontalPodAutoscaler that scales the Deployment or the StatefulSet of the application on its CPU
+// and memory utilization and on the additional metrics. Without any metric, the autoscaler targets 80% CPU utilization.
+func CustomizeHPA(hpa *autoscalingv2beta2.HorizontalPodAutoscaler, la *webspherelibertyv1.WebSphereLibertyApplication) {
+	autoscaling := la.Spec.Autoscaling
+	hpa.Labels = la.GetLabels()
+	hpa.Annotations = rcoutils.MergeMaps(hpa.Annotations, la.GetAnnotations())
+
+	hpa.Spec.MaxReplicas = autoscaling.GetMaxReplicas()
+	hpa.Spec.MinReplicas = autoscaling.GetMinReplicas()
+	hpa.Spec.ScaleTargetRef = autoscalingv2beta2.CrossVersionObjectReference{
+		APIVersion: "apps/v1",
+		Kind:       "Deployment",
+		Name:       la.GetName(),
+	}
+	if la.Spec.StatefulSet != nil {
+		hpa.Spec.ScaleTargetRef.Kind = "StatefulSet"
+	}
+
+	var metrics []autoscalingv2beta2.MetricSpec
+	if autoscaling.TargetCPUUtilizationPercentage != nil {
+		metrics = append(metrics, getResourceUtilizationMetric(corev1.ResourceCPU, *autoscaling.TargetCPUUtilizationPercentage))
+	}
+	if autoscaling.TargetMemoryUtilizationPercentage != nil {
+		metrics = append(metrics, getResourceUtilizationMetric(corev1.ResourceMemory, *autoscaling.TargetMemoryUtilizationPercentage))
+	}
+	hpa.Spec.Metrics = append(metrics, autoscaling.Metrics...)
+	hpa.Spec.Behavior = autoscaling.Behavior
+}
+
+func getResourceUtilizationMetric(name corev1.ResourceName, utilization int32) autoscalingv2beta2.MetricSpec {
+	return autoscalingv2beta2.MetricSpec{
+		Type: autoscalingv2beta2.ResourceMetricSourceType,
+		Resource: &autoscalingv2beta2.ResourceMetricSource{
+			Name: name,
+			Target: autoscalingv2beta2.MetricTarget{
+				Type:               autoscalingv2beta2.UtilizationMetricType,
+				AverageUtilization: &utilization,
+			},
+		},
+	}
+}
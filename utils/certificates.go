@@ -0,0 +1,95 @@
+package utils
+
+import (
+	webspherelibertyv1 "github.com/WASdev/websphere-liberty-operator/api/v1"
+	rcoutils "github.com/application-stacks/runtime-component-operator/utils"
+	certmanagerv1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+)
+
+// Keys of the operator ConfigMap that configure the certificates issued by cert-manager
+const (
+	// OpConfigCertManagerIssuer is the name of the cert-manager issuer used for application certificates.
+	// Certificates are only created when it is set.
+	OpConfigCertManagerIssuer = "certManagerIssuer"
+	// OpConfigCertManagerIssuerKind is the kind of the cert-manager issuer, either ClusterIssuer or Issuer. Defaults to ClusterIssuer.
+	OpConfigCertManagerIssuerKind = "certManagerIssuerKind"
+)
+
+// GetCertManagerIssuerRef returns the issuer configured in the operator ConfigMap, or nil if none is configured
+func GetCertManagerIssuerRef() *cmmeta.ObjectReference {
+	if issuer := GetOperatorConfig().CertManagerIssuer; issuer != nil {
+		return issuer.DeepCopy()
+	}
+	return nil
+}
+
+// GetRouteHost returns the host used by the Route or Ingress of the application, or an empty string if it can't be determined
+func GetRouteHost(la *webspherelibertyv1.WebSphereLibertyApplication) string {
+	host := ""
+	if la.Spec.Route != nil {
+		host = la.Spec.Route.Host
+	}
+	if defaultHostname := GetOperatorConfig().DefaultHostname; host == "" && defaultHostname != "" {
+		host = la.GetName() + "-" + la.GetNamespace() + "." + defaultHostname
+	}
+	return host
+}
+
+// GetRouteCertificateHosts returns the hosts of the certificate issued for the application Route or Ingress. When spec.ingress
+// is specified, the certificate is issued for the hosts of its rules that do not reference their own TLS secret.
+func GetRouteCertificateHosts(la *webspherelibertyv1.WebSphereLibertyApplication) []string {
+	ingress := la.GetIngress()
+	if ingress == nil {
+		if host := GetRouteHost(la); host != "" {
+			return []string{host}
+		}
+		return nil
+	}
+	var hosts []string
+	seen := map[string]bool{}
+	for _, rule := range ingress.Rules {
+		if rule.Host != "" && rule.TLSSecretName == "" && !seen[rule.Host] {
+			seen[rule.Host] = true
+			hosts = append(hosts, rule.Host)
+		}
+	}
+	return hosts
+}
+
+// CustomizeServiceCertificate configures a Certificate for the DNS names of the application Service
+func CustomizeServiceCertificate(cert *certmanagerv1.Certificate, la *webspherelibertyv1.WebSphereLibertyApplication, issuerRef cmmeta.ObjectReference) {
+	cert.Labels = la.GetLabels()
+	cert.Annotations = rcoutils.MergeMaps(cert.Annotations, la.GetAnnotations())
+
+	svcName := la.GetName()
+	namespace := la.GetNamespace()
+	cert.Spec.DNSNames = []string{
+		svcName,
+		svcName + "." + namespace,
+		svcName + "." + namespace + ".svc",
+		svcName + "." + namespace + ".svc.cluster.local",
+	}
+	cert.Spec.SecretName = cert.Name
+	cert.Spec.IssuerRef = issuerRef
+}
+
+// CustomizeRouteCertificate configures a Certificate for the hosts of the application Route or Ingress
+func CustomizeRouteCertificate(cert *certmanagerv1.Certificate, la *webspherelibertyv1.WebSphereLibertyApplication, hosts []string, issuerRef cmmeta.ObjectReference) {
+	cert.Labels = la.GetLabels()
+	cert.Annotations = rcoutils.MergeMaps(cert.Annotations, la.GetAnnotations())
+
+	cert.Spec.DNSNames = hosts
+	cert.Spec.SecretName = cert.Name
+	cert.Spec.IssuerRef = issuerRef
+}
+
+// IsCertificateReady returns true if cert-manager has issued the Certificate into its secret
+func IsCertificateReady(cert *certmanagerv1.Certificate) bool {
+	for _, c := range cert.Status.Conditions {
+		if c.Type == certmanagerv1.CertificateConditionReady {
+			return c.Status == cmmeta.ConditionTrue
+		}
+	}
+	return false
+}
@@ -0,0 +1,163 @@
+package utils
+
+import (
+	webspherelibertyv1 "github.com/WASdev/websphere-liberty-operator/api/v1"
+	rcoutils "github.com/application-stacks/runtime-component-operator/utils"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Exposure through the Gateway API
+const gatewayAPIGroup = "gateway.networking.k8s.io"
+const defaultGatewayPathType = "PathPrefix"
+const defaultGatewayHeaderMatchType = "Exact"
+
+// HTTPRouteGroupVersionKinds identifies the versions of the Gateway API HTTPRoute the operator can manage, in order of preference
+var HTTPRouteGroupVersionKinds = []schema.GroupVersionKind{
+	{Group: gatewayAPIGroup, Version: "v1", Kind: "HTTPRoute"},
+	{Group: gatewayAPIGroup, Version: "v1beta1", Kind: "HTTPRoute"},
+}
+
+// IsGatewayRouteEnabled returns true if the application is exposed through a Gateway API HTTPRoute instead of a Route or an Ingress
+func IsGatewayRouteEnabled(la *webspherelibertyv1.WebSphereLibertyApplication) bool {
+	return la.Spec.Expose != nil && *la.Spec.Expose && la.GetGatewayRoute() != nil
+}
+
+// GetGatewayNamespace returns the namespace of the Gateway the HTTPRoute of the application is attached to
+func GetGatewayNamespace(la *webspherelibertyv1.WebSphereLibertyApplication) string {
+	if ns := la.GetGatewayRoute().Gateway.Namespace; ns != "" {
+		return ns
+	}
+	return la.GetNamespace()
+}
+
+// getHTTPRouteHostnames returns the hostnames matched by the HTTPRoute of the application
+func getHTTPRouteHostnames(la *webspherelibertyv1.WebSphereLibertyApplication) []string {
+	if hostnames := la.GetGatewayRoute().Hostnames; len(hostnames) > 0 {
+		return hostnames
+	}
+	if la.Spec.Route != nil && la.Spec.Route.GetHost() != "" {
+		return []string{la.Spec.Route.GetHost()}
+	}
+	return nil
+}
+
+// getHTTPRouteMatches returns the requests matched by the HTTPRoute of the application
+func getHTTPRouteMatches(la *webspherelibertyv1.WebSphereLibertyApplication) []webspherelibertyv1.WebSphereLibertyApplicationGatewayRouteMatch {
+	if matches := la.GetGatewayRoute().Matches; len(matches) > 0 {
+		return matches
+	}
+	path := "/"
+	if la.Spec.Route != nil && la.Spec.Route.GetPath() != "" {
+		path = la.Spec.Route.GetPath()
+	}
+	return []webspherelibertyv1.WebSphereLibertyApplicationGatewayRouteMatch{{Path: path}}
+}
+
+// CustomizeHTTPRoute attaches the HTTPRoute of the application to its Gateway and sends the matched requests to the application
+// Service. The defaults of the Gateway API are set explicitly, so that the HTTPRoute read back from the cluster is not updated on
+// every reconcile. While an image is rolled out, the traffic is split with the Service of the new image.
+func CustomizeHTTPRoute(route *unstructured.Unstructured, la *webspherelibertyv1.WebSphereLibertyApplication) error {
+	gr := la.GetGatewayRoute()
+	route.SetLabels(rcoutils.MergeMaps(route.GetLabels(), la.GetLabels()))
+	route.SetAnnotations(rcoutils.MergeMaps(route.GetAnnotations(), la.GetAnnotations(), gr.Annotations))
+
+	parentRef := map[string]interface{}{
+		"group":     gatewayAPIGroup,
+		"kind":      "Gateway",
+		"name":      gr.Gateway.Name,
+		"namespace": GetGatewayNamespace(la),
+	}
+	if gr.Gateway.SectionName != "" {
+		parentRef["sectionName"] = gr.Gateway.SectionName
+	}
+	spec := map[string]interface{}{
+		"parentRefs": []interface{}{parentRef},
+	}
+
+	if hostnames := getHTTPRouteHostnames(la); len(hostnames) > 0 {
+		values := []interface{}{}
+		for _, hostname := range hostnames {
+			values = append(values, hostname)
+		}
+		spec["hostnames"] = values
+	}
+
+	matches := []interface{}{}
+	for _, m := range getHTTPRouteMatches(la) {
+		path, pathType := m.Path, m.PathType
+		if path == "" {
+			path = "/"
+		}
+		if pathType == "" {
+			pathType = defaultGatewayPathType
+		}
+		match := map[string]interface{}{
+			"path": map[string]interface{}{"type": pathType, "value": path},
+		}
+		if len(m.Headers) > 0 {
+			headers := []interface{}{}
+			for _, h := range m.Headers {
+				headerType := h.Type
+				if headerType == "" {
+					headerType = defaultGatewayHeaderMatchType
+				}
+				headers = append(headers, map[string]interface{}{"name": h.Name, "type": headerType, "value": h.Value})
+			}
+			match["headers"] = headers
+		}
+		matches = append(matches, match)
+	}
+
+	port := int64(la.Spec.Service.GetPort())
+	weight := int64(GetRolloutWeight(la))
+	backendRefs := []interface{}{
+		map[string]interface{}{"group": "", "kind": "Service", "name": la.GetName(), "port": port, "weight": 100 - weight},
+	}
+	if weight > 0 {
+		backendRefs = append(backendRefs, map[string]interface{}{"group": "", "kind": "Service", "name": GetRolloutName(la), "port": port, "weight": weight})
+	}
+
+	spec["rules"] = []interface{}{
+		map[string]interface{}{"matches": matches, "backendRefs": backendRefs},
+	}
+	return unstructured.SetNestedField(route.Object, spec, "spec")
+}
+
+// GetHTTPRouteEndpoint returns the URL of the application exposed by an HTTPRoute, or an empty string if it has no hostname.
+// The Gateway terminates TLS, so the scheme is https when the listener the HTTPRoute is attached to uses the HTTPS protocol.
+func GetHTTPRouteEndpoint(la *webspherelibertyv1.WebSphereLibertyApplication, gateway *unstructured.Unstructured) string {
+	sectionName := la.GetGatewayRoute().Gateway.SectionName
+	hostname, scheme := "", "http"
+	if hostnames := getHTTPRouteHostnames(la); len(hostnames) > 0 {
+		hostname = hostnames[0]
+	}
+
+	listeners, _, _ := unstructured.NestedSlice(gateway.Object, "spec", "listeners")
+	for _, l := range listeners {
+		listener, ok := l.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if name, _, _ := unstructured.NestedString(listener, "name"); sectionName != "" && name != sectionName {
+			continue
+		}
+		if hostname == "" {
+			hostname, _, _ = unstructured.NestedString(listener, "hostname")
+		}
+		if protocol, _, _ := unstructured.NestedString(listener, "protocol"); protocol == "HTTPS" {
+			scheme = "https"
+		}
+		break
+	}
+	// A wildcard hostname of the listener does not identify the application
+	if hostname == "" || hostname[0] == '*' {
+		return ""
+	}
+
+	path := ""
+	if m := getHTTPRouteMatches(la)[0]; m.Path != "" && m.Path != "/" && m.PathType != "RegularExpression" {
+		path = m.Path
+	}
+	return scheme + "://" + hostname + path
+}
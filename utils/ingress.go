@@ -0,0 +1,79 @@
+package utils
+
+import (
+	"strconv"
+
+	webspherelibertyv1 "github.com/WASdev/websphere-liberty-operator/api/v1"
+	rcoutils "github.com/application-stacks/runtime-component-operator/utils"
+	networkingv1 "k8s.io/api/networking/v1"
+)
+
+// CustomizeIngress configures the Ingress of the application from spec.ingress, or from the host and path of spec.route when
+// spec.ingress is not specified. The hosts of spec.ingress that do not reference their own TLS secret use the certificate of
+// spec.route.certificateSecretRef, which is issued for them when cert-manager is configured.
+func CustomizeIngress(ing *networkingv1.Ingress, la *webspherelibertyv1.WebSphereLibertyApplication) {
+	ingress := la.GetIngress()
+	if ingress == nil {
+		rcoutils.CustomizeIngress(ing, la)
+		return
+	}
+
+	ing.Labels = la.GetLabels()
+	ing.Annotations = rcoutils.MergeMaps(ing.Annotations, la.GetAnnotations(), ingress.Annotations)
+	ing.Spec.IngressClassName = ingress.IngressClassName
+	ing.Spec.DefaultBackend = nil
+	ing.Spec.Rules = nil
+	ing.Spec.TLS = nil
+	for _, rule := range ingress.Rules {
+		paths := rule.Paths
+		if len(paths) == 0 {
+			paths = []webspherelibertyv1.WebSphereLibertyApplicationIngressPath{{}}
+		}
+		httpPaths := []networkingv1.HTTPIngressPath{}
+		for _, p := range paths {
+			path := p.Path
+			if path == "" {
+				path = "/"
+			}
+			pathType := networkingv1.PathTypePrefix
+			if p.PathType != nil {
+				pathType = *p.PathType
+			}
+			httpPaths = append(httpPaths, networkingv1.HTTPIngressPath{
+				Path:     path,
+				PathType: &pathType,
+				Backend: networkingv1.IngressBackend{
+					Service: &networkingv1.IngressServiceBackend{
+						Name: la.GetName(),
+						Port: getIngressServiceBackendPort(la, p.Port),
+					},
+				},
+			})
+		}
+		ing.Spec.Rules = append(ing.Spec.Rules, networkingv1.IngressRule{
+			Host: rule.Host,
+			IngressRuleValue: networkingv1.IngressRuleValue{
+				HTTP: &networkingv1.HTTPIngressRuleValue{Paths: httpPaths},
+			},
+		})
+		secretName := rule.TLSSecretName
+		if secretName == "" && rule.Host != "" && la.Spec.Route != nil && la.Spec.Route.CertificateSecretRef != nil {
+			secretName = *la.Spec.Route.CertificateSecretRef
+		}
+		if secretName != "" {
+			ing.Spec.TLS = append(ing.Spec.TLS, networkingv1.IngressTLS{Hosts: []string{rule.Host}, SecretName: secretName})
+		}
+	}
+}
+
+// getIngressServiceBackendPort returns the port of the application Service the requests are sent to. Like the other resources
+// of the application, spec.service.port is referenced by its name.
+func getIngressServiceBackendPort(la *webspherelibertyv1.WebSphereLibertyApplication, port int32) networkingv1.ServiceBackendPort {
+	if port != 0 && port != la.Spec.Service.GetPort() {
+		return networkingv1.ServiceBackendPort{Number: port}
+	}
+	if la.Spec.Service != nil && la.Spec.Service.PortName != "" {
+		return networkingv1.ServiceBackendPort{Name: la.Spec.Service.PortName}
+	}
+	return networkingv1.ServiceBackendPort{Name: strconv.Itoa(int(la.Spec.Service.GetPort())) + "-tcp"}
+}
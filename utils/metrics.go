@@ -0,0 +1,129 @@
+package utils
+
+import (
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// Prometheus metrics of the operator, served with the controller-runtime metrics on the metrics endpoint of the manager
+const metricsNamespace = "websphere_liberty_operator"
+const metricsResultSuccess = "success"
+const metricsResultError = "error"
+
+// Reasons of the failed reconciles that are not caused by an error of the Kubernetes API
+const (
+	ReconcileReasonInvalidSpec               = "InvalidSpec"
+	ReconcileReasonAPINotSupported           = "APINotSupported"
+	ReconcileReasonTransactionRecoveryFailed = "TransactionRecoveryFailed"
+	ReconcileReasonPodNotFound               = "PodNotFound"
+	ReconcileReasonPodNotRunning             = "PodNotRunning"
+	ReconcileReasonExecFailed                = "ExecFailed"
+)
+
+var (
+	reconcileTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "reconcile_total",
+		Help:      "Total number of reconciles of the custom resources, by kind and result",
+	}, []string{"kind", "result"})
+
+	reconcileErrorsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "reconcile_errors_total",
+		Help:      "Total number of failed reconciles of the custom resources, by kind and reason of the error",
+	}, []string{"kind", "reason"})
+
+	operationsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "operations_total",
+		Help:      "Total number of day-2 operations run in the application pods, by kind and result",
+	}, []string{"kind", "result"})
+
+	operationDurationSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "operation_duration_seconds",
+		Help:      "Duration of the day-2 operations run in the application pods, by kind",
+		Buckets:   prometheus.ExponentialBuckets(0.25, 2, 10),
+	}, []string{"kind"})
+
+	execFailuresTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "exec_failures_total",
+		Help:      "Total number of commands that failed to run in the containers of the application pods",
+	})
+
+	oidcRegistrationsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "oidc_registrations_total",
+		Help:      "Total number of client registrations with OpenID Connect providers, by result",
+	}, []string{"result"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(reconcileTotal, reconcileErrorsTotal, operationsTotal, operationDurationSeconds,
+		execFailuresTotal, oidcRegistrationsTotal)
+}
+
+// metricsResult returns the result label of an outcome
+func metricsResult(issue error) string {
+	if issue != nil {
+		return metricsResultError
+	}
+	return metricsResultSuccess
+}
+
+// ReconcileError is an error of the operator, as opposed to an error returned by the Kubernetes API, with the reason that failed
+// reconciles are counted by
+type ReconcileError struct {
+	Reason string
+	Err    error
+}
+
+// NewReconcileError returns the error with the reason that failed reconciles are counted by
+func NewReconcileError(reason string, err error) error {
+	return &ReconcileError{Reason: reason, Err: err}
+}
+
+func (e *ReconcileError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ReconcileError) Unwrap() error {
+	return e.Err
+}
+
+// GetReconcileErrorReason returns the reason of a failed reconcile: the reason of a ReconcileError, the reason of an error
+// returned by the Kubernetes API, such as Conflict or Forbidden, or ReconcileFailed for any other error
+func GetReconcileErrorReason(issue error) string {
+	var reconcileErr *ReconcileError
+	if errors.As(issue, &reconcileErr) {
+		return reconcileErr.Reason
+	}
+	var apiErr kerrors.APIStatus
+	if errors.As(issue, &apiErr) && apiErr.Status().Reason != "" {
+		return string(apiErr.Status().Reason)
+	}
+	return StatusReasonReconcileFailed
+}
+
+// RecordReconcile counts the outcome of a reconcile of a custom resource of the kind. Failed reconciles are also counted by
+// their reason, as returned by GetReconcileErrorReason.
+func RecordReconcile(kind string, issue error) {
+	reconcileTotal.WithLabelValues(kind, metricsResult(issue)).Inc()
+	if issue == nil {
+		return
+	}
+	reconcileErrorsTotal.WithLabelValues(kind, GetReconcileErrorReason(issue)).Inc()
+}
+
+// RecordOperation counts a day-2 operation of the kind, such as a server dump or a trace, and observes its duration since the
+// start of the operation. The outcome of the operation is also the outcome of the reconcile of its custom resource.
+func RecordOperation(kind string, start time.Time, issue error) {
+	operationsTotal.WithLabelValues(kind, metricsResult(issue)).Inc()
+	operationDurationSeconds.WithLabelValues(kind).Observe(time.Since(start).Seconds())
+	RecordReconcile(kind, issue)
+}
@@ -0,0 +1,157 @@
+package utils
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"strconv"
+
+	webspherelibertyv1 "github.com/WASdev/websphere-liberty-operator/api/v1"
+	rcoutils "github.com/application-stacks/runtime-component-operator/utils"
+	prometheusv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// MetricsCredentialsSecretSuffix is appended to the application name to name the Secret with the generated credentials of the
+// Liberty user that Prometheus authenticates with to read the /metrics endpoint
+const MetricsCredentialsSecretSuffix = "-metrics-credentials"
+
+const metricsCredentialsVolume = "metrics-credentials"
+const metricsCredentialsFile = "metrics-credentials.xml"
+const metricsUsername = "metrics"
+const metricsRegistryID = "metrics-registry"
+
+// GetMetricsCredentialsSecretName returns the name of the Secret with the credentials Prometheus uses to read the metrics
+func GetMetricsCredentialsSecretName(la *webspherelibertyv1.WebSphereLibertyApplication) string {
+	if la.Spec.Monitoring != nil && la.Spec.Monitoring.GetCredentialsSecretRef() != nil {
+		return *la.Spec.Monitoring.GetCredentialsSecretRef()
+	}
+	return la.GetName() + MetricsCredentialsSecretSuffix
+}
+
+// IsMetricsCredentialsSecretGenerated returns true if the operator generates the credentials Prometheus uses to read the metrics.
+// They are not generated when the application brings its own user registry, which the generated basicRegistry would conflict with.
+func IsMetricsCredentialsSecretGenerated(la *webspherelibertyv1.WebSphereLibertyApplication) bool {
+	if la.Spec.Monitoring == nil || la.Spec.Monitoring.GetCredentialsSecretRef() != nil {
+		return false
+	}
+	return la.Spec.Monitoring.GenerateCredentials == nil || *la.Spec.Monitoring.GenerateCredentials
+}
+
+// CustomizeMetricsCredentialsSecret generates the credentials of the metrics user, unless they already exist, and the
+// Liberty configuration that adds the user to a basicRegistry. The user is only granted the reader-role, which is enough to
+// read the /metrics endpoint, rather than the administrator-role that quickStartSecurity grants.
+func CustomizeMetricsCredentialsSecret(secret *corev1.Secret, la *webspherelibertyv1.WebSphereLibertyApplication) error {
+	secret.Labels = la.GetLabels()
+	secret.Annotations = rcoutils.MergeMaps(secret.Annotations, la.GetAnnotations())
+
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	if len(secret.Data["username"]) == 0 {
+		secret.Data["username"] = []byte(metricsUsername)
+	}
+	if len(secret.Data["password"]) == 0 {
+		password := make([]byte, 24)
+		if _, err := rand.Read(password); err != nil {
+			return err
+		}
+		secret.Data["password"] = []byte(base64.RawURLEncoding.EncodeToString(password))
+	}
+	secret.Data[metricsCredentialsFile] = []byte("<server>\n" +
+		"  <basicRegistry id=\"" + metricsRegistryID + "\" realm=\"" + metricsRegistryID + "\">\n" +
+		"    <user name=\"" + string(secret.Data["username"]) + "\" password=\"" + string(secret.Data["password"]) + "\"/>\n" +
+		"  </basicRegistry>\n" +
+		"  <reader-role>\n" +
+		"    <user>" + string(secret.Data["username"]) + "</user>\n" +
+		"  </reader-role>\n" +
+		"</server>\n")
+	return nil
+}
+
+// CustomizeMetricsCredentials mounts the Liberty configuration of the generated metrics user into the configDropins defaults directory
+func CustomizeMetricsCredentials(pts *corev1.PodTemplateSpec, la *webspherelibertyv1.WebSphereLibertyApplication) {
+	if !IsMetricsCredentialsSecretGenerated(la) {
+		return
+	}
+
+	foundVolumeMount := false
+	for _, v := range pts.Spec.Containers[0].VolumeMounts {
+		if v.Name == metricsCredentialsVolume {
+			foundVolumeMount = true
+		}
+	}
+	if !foundVolumeMount {
+		pts.Spec.Containers[0].VolumeMounts = append(pts.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+			Name:      metricsCredentialsVolume,
+			MountPath: serverConfigDefaultsDir + "/" + metricsCredentialsFile,
+			SubPath:   metricsCredentialsFile,
+			ReadOnly:  true,
+		})
+	}
+
+	foundVolume := false
+	for _, v := range pts.Spec.Volumes {
+		if v.Name == metricsCredentialsVolume {
+			foundVolume = true
+		}
+	}
+	if !foundVolume {
+		pts.Spec.Volumes = append(pts.Spec.Volumes, corev1.Volume{
+			Name: metricsCredentialsVolume,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: GetMetricsCredentialsSecretName(la),
+					Items:      []corev1.KeyToPath{{Key: metricsCredentialsFile, Path: metricsCredentialsFile}},
+				},
+			},
+		})
+	}
+}
+
+// CustomizeServiceMonitor points the endpoints of the ServiceMonitor to the Liberty /metrics endpoint over https, with the TLS
+// configuration of the service and the credentials of the metrics user. Without the certificate of the service, the certificate
+// of the server is not verified. Fields set in spec.monitoring.endpoints are kept.
+func CustomizeServiceMonitor(sm *prometheusv1.ServiceMonitor, la *webspherelibertyv1.WebSphereLibertyApplication) {
+	if len(sm.Spec.Endpoints) == 0 {
+		portName := la.Spec.Service.PortName
+		if portName == "" {
+			portName = strconv.Itoa(int(la.Spec.Service.Port)) + "-tcp"
+		}
+		sm.Spec.Endpoints = []prometheusv1.Endpoint{{Port: portName}}
+	}
+
+	certSecretRef := la.Spec.Service.CertificateSecretRef
+	hasCredentials := IsMetricsCredentialsSecretGenerated(la) || (la.Spec.Monitoring != nil && la.Spec.Monitoring.GetCredentialsSecretRef() != nil)
+	for i := range sm.Spec.Endpoints {
+		endpoint := &sm.Spec.Endpoints[i]
+		if endpoint.Path == "" {
+			endpoint.Path = "/metrics"
+		}
+		if endpoint.Scheme == "" {
+			endpoint.Scheme = "https"
+		}
+		if endpoint.Scheme == "https" && endpoint.TLSConfig == nil {
+			endpoint.TLSConfig = &prometheusv1.TLSConfig{
+				ServerName: la.GetName() + "." + la.GetNamespace() + ".svc",
+			}
+			if certSecretRef != nil {
+				endpoint.TLSConfig.CA = prometheusv1.SecretOrConfigMap{
+					Secret: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: *certSecretRef},
+						Key:                  "ca.crt",
+					},
+				}
+			} else {
+				endpoint.TLSConfig.InsecureSkipVerify = true
+			}
+		}
+		// Basic auth is never sent in plain text, and only when there are credentials to send
+		if endpoint.BasicAuth == nil && endpoint.Scheme == "https" && hasCredentials {
+			secretRef := corev1.LocalObjectReference{Name: GetMetricsCredentialsSecretName(la)}
+			endpoint.BasicAuth = &prometheusv1.BasicAuth{
+				Username: corev1.SecretKeySelector{LocalObjectReference: secretRef, Key: "username"},
+				Password: corev1.SecretKeySelector{LocalObjectReference: secretRef, Key: "password"},
+			}
+		}
+	}
+}
@@ -0,0 +1,201 @@
+package utils
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	webspherelibertyv1 "github.com/WASdev/websphere-liberty-operator/api/v1"
+	"github.com/application-stacks/runtime-component-operator/common"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/yaml"
+)
+
+// OperatorConfigMapName is the name of the ConfigMap with the configuration of the operator, in the namespace of the operator
+const OperatorConfigMapName = "websphere-liberty-operator"
+
+// Keys of the operator ConfigMap that set the defaults of the applications
+const (
+	// OpConfigDefaultPullPolicy is the image pull policy of the applications that do not set spec.pullPolicy. Defaults to IfNotPresent.
+	OpConfigDefaultPullPolicy = "defaultPullPolicy"
+	// OpConfigDefaultResources is the YAML of the resource requirements of the applications that set neither requests nor limits
+	// in spec.resourceConstraints
+	OpConfigDefaultResources = "defaultResources"
+	// The default probes also enable the Liberty health probes for the applications that do not set spec.defaultHealthProbes.
+	// OpConfigDefaultLivenessProbe is the YAML of a probe whose fields override the default liveness probe of the applications
+	OpConfigDefaultLivenessProbe = "defaultLivenessProbe"
+	// OpConfigDefaultReadinessProbe is the YAML of a probe whose fields override the default readiness probe of the applications
+	OpConfigDefaultReadinessProbe = "defaultReadinessProbe"
+	// OpConfigDefaultStartupProbe is the YAML of a probe whose fields override the default startup probe of the applications
+	OpConfigDefaultStartupProbe = "defaultStartupProbe"
+)
+
+// OperatorConfig is the validated configuration of the operator
+type OperatorConfig struct {
+	DefaultHostname       string
+	DefaultTopologySpread bool
+	DefaultPullPolicy     corev1.PullPolicy
+	DefaultResources      *corev1.ResourceRequirements
+	DefaultLivenessProbe  *corev1.Probe
+	DefaultReadinessProbe *corev1.Probe
+	DefaultStartupProbe   *corev1.Probe
+	CertManagerIssuer     *cmmeta.ObjectReference
+	// NetworkPolicyIngressNamespace is the namespace of the ingress controller on Kubernetes
+	NetworkPolicyIngressNamespace string
+
+	// data holds the valid keys of the ConfigMap, including the keys read by the runtime component operator
+	data common.OpConfig
+}
+
+var operatorConfig atomic.Value
+
+// operatorConfigLock is held for reading by the reconciles that use the configuration, including the functions of the runtime
+// component operator that read common.Config, and for writing while the configuration is replaced
+var operatorConfigLock sync.RWMutex
+
+func init() {
+	SetOperatorConfig(DefaultOperatorConfig())
+}
+
+// DefaultOperatorConfig returns the configuration of the operator when its ConfigMap does not exist
+func DefaultOperatorConfig() *OperatorConfig {
+	return &OperatorConfig{
+		DefaultTopologySpread: true,
+		DefaultPullPolicy:     corev1.PullIfNotPresent,
+		data:                  common.DefaultOpConfig(),
+	}
+}
+
+// GetOperatorConfig returns the current configuration of the operator. It must not be modified.
+func GetOperatorConfig() *OperatorConfig {
+	return operatorConfig.Load().(*OperatorConfig)
+}
+
+// LockOperatorConfig keeps the configuration of the operator from being replaced until the returned function is called, so
+// that a reconcile sees the same configuration from start to end, including in common.Config
+func LockOperatorConfig() func() {
+	operatorConfigLock.RLock()
+	return operatorConfigLock.RUnlock
+}
+
+// SetOperatorConfig replaces the configuration of the operator once the reconciles that locked it are done. The configuration
+// must not be modified once it is set.
+func SetOperatorConfig(cfg *OperatorConfig) {
+	operatorConfigLock.Lock()
+	defer operatorConfigLock.Unlock()
+
+	operatorConfig.Store(cfg)
+	common.Config = cfg.data
+	webspherelibertyv1.SetOperatorDefaults(&webspherelibertyv1.OperatorDefaults{
+		PullPolicy:     cfg.DefaultPullPolicy,
+		Resources:      cfg.DefaultResources,
+		LivenessProbe:  cfg.DefaultLivenessProbe,
+		ReadinessProbe: cfg.DefaultReadinessProbe,
+		StartupProbe:   cfg.DefaultStartupProbe,
+	})
+}
+
+// ParseOperatorConfig returns the configuration in the operator ConfigMap, or the default configuration if the ConfigMap is nil.
+// An error is returned for each key that is unknown or has an invalid value, and the default is used for the key.
+func ParseOperatorConfig(cm *corev1.ConfigMap) (*OperatorConfig, []error) {
+	cfg := DefaultOperatorConfig()
+	if cm == nil {
+		return cfg, nil
+	}
+
+	keys := []string{}
+	for key := range cm.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var errs []error
+	issuerKind := "ClusterIssuer"
+	for _, key := range keys {
+		if err := cfg.set(key, cm.Data[key], &issuerKind); err != nil {
+			errs = append(errs, fmt.Errorf("Invalid key %s in ConfigMap %s: %v", key, cm.Name, err))
+			continue
+		}
+		cfg.data[key] = cm.Data[key]
+	}
+	if cfg.CertManagerIssuer != nil {
+		cfg.CertManagerIssuer.Kind = issuerKind
+	}
+	return cfg, errs
+}
+
+// set validates the value of the key and sets it in the configuration
+func (cfg *OperatorConfig) set(key string, value string, issuerKind *string) error {
+	switch key {
+	case common.OpConfigDefaultHostname:
+		if value != "" {
+			if msgs := validation.IsDNS1123Subdomain(value); len(msgs) > 0 {
+				return fmt.Errorf("%s", strings.Join(msgs, ", "))
+			}
+		}
+		cfg.DefaultHostname = value
+	case OpConfigDefaultTopologySpread:
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("must be true or false")
+		}
+		cfg.DefaultTopologySpread = enabled
+	case OpConfigDefaultPullPolicy:
+		switch pp := corev1.PullPolicy(value); pp {
+		case corev1.PullAlways, corev1.PullIfNotPresent, corev1.PullNever:
+			cfg.DefaultPullPolicy = pp
+		default:
+			return fmt.Errorf("must be one of Always, IfNotPresent, Never")
+		}
+	case OpConfigDefaultResources:
+		resources := &corev1.ResourceRequirements{}
+		if err := yaml.UnmarshalStrict([]byte(value), resources); err != nil {
+			return err
+		}
+		cfg.DefaultResources = resources
+	case OpConfigDefaultLivenessProbe, OpConfigDefaultReadinessProbe, OpConfigDefaultStartupProbe:
+		probe := &corev1.Probe{}
+		if err := yaml.UnmarshalStrict([]byte(value), probe); err != nil {
+			return err
+		}
+		switch key {
+		case OpConfigDefaultLivenessProbe:
+			cfg.DefaultLivenessProbe = probe
+		case OpConfigDefaultReadinessProbe:
+			cfg.DefaultReadinessProbe = probe
+		default:
+			cfg.DefaultStartupProbe = probe
+		}
+	case OpConfigNetworkPolicyIngressNamespace:
+		if value != "" {
+			if msgs := validation.IsDNS1123Label(value); len(msgs) > 0 {
+				return fmt.Errorf("%s", strings.Join(msgs, ", "))
+			}
+		}
+		cfg.NetworkPolicyIngressNamespace = value
+	case OpConfigCertManagerIssuer:
+		if value == "" {
+			return nil
+		}
+		if msgs := validation.IsDNS1123Subdomain(value); len(msgs) > 0 {
+			return fmt.Errorf("%s", strings.Join(msgs, ", "))
+		}
+		cfg.CertManagerIssuer = &cmmeta.ObjectReference{Name: value, Group: "cert-manager.io"}
+	case OpConfigCertManagerIssuerKind:
+		if value != "ClusterIssuer" && value != "Issuer" {
+			return fmt.Errorf("must be one of ClusterIssuer, Issuer")
+		}
+		*issuerKind = value
+	default:
+		// The keys of the runtime component operator are passed through
+		if _, ok := cfg.data[key]; !ok {
+			return fmt.Errorf("unknown key")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package utils
+
+import (
+	"context"
+	"strings"
+
+	webspherelibertyv1 "github.com/WASdev/websphere-liberty-operator/api/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ReconcilePausedAnnotation pauses the reconciliation of an application, a dump or a trace when it is set to "true". While it is
+// paused, the operator only reports the Paused condition, so that the resources it manages can be edited by hand.
+const ReconcilePausedAnnotation = "liberty.websphere.ibm.com/reconcile-paused"
+
+// StatusReasonReconcilePaused is the reason of the Paused condition while the reconciliation is paused
+const StatusReasonReconcilePaused = "ReconcilePaused"
+
+const reconcilePausedMessage = "Reconciliation is paused by the " + ReconcilePausedAnnotation + " annotation"
+
+// IsReconcilePaused returns true if the reconciliation of the resource is paused by its annotation
+func IsReconcilePaused(obj metav1.Object) bool {
+	return strings.EqualFold(obj.GetAnnotations()[ReconcilePausedAnnotation], "true")
+}
+
+// IsReconcilePausedChanged returns true if the reconciliation of the resource was paused or resumed by the update
+func IsReconcilePausedChanged(oldObj metav1.Object, newObj metav1.Object) bool {
+	return IsReconcilePaused(oldObj) != IsReconcilePaused(newObj)
+}
+
+// SetPausedCondition sets the Paused condition of the application while its reconciliation is paused, and clears it once the
+// reconciliation is resumed. It returns true if the condition changed.
+func SetPausedCondition(la *webspherelibertyv1.WebSphereLibertyApplication) bool {
+	condition := getStatusCondition(la, webspherelibertyv1.StatusConditionTypePaused)
+	if IsReconcilePaused(la) {
+		if condition != nil && condition.Status == corev1.ConditionTrue {
+			return false
+		}
+		SetStatusCondition(la, webspherelibertyv1.StatusConditionTypePaused, corev1.ConditionTrue, StatusReasonReconcilePaused, reconcilePausedMessage)
+		return true
+	}
+	if condition != nil && condition.Status == corev1.ConditionTrue {
+		SetStatusCondition(la, webspherelibertyv1.StatusConditionTypePaused, corev1.ConditionFalse, "", "")
+		return true
+	}
+	return false
+}
+
+// ReconcileOperationPaused reports the Paused condition of a day-2 operation while its reconciliation is paused, and clears it
+// once the reconciliation is resumed. It returns true if the reconciliation is paused. The status is only updated when the
+// condition changes.
+func ReconcileOperationPaused(c client.Client, obj client.Object, conditions *[]webspherelibertyv1.OperationStatusCondition) (bool, error) {
+	paused := IsReconcilePaused(obj)
+	oc := webspherelibertyv1.GetOperationCondtion(*conditions, webspherelibertyv1.OperationStatusConditionTypePaused)
+	if paused == (oc != nil && oc.Status == corev1.ConditionTrue) {
+		return paused, nil
+	}
+
+	condition := webspherelibertyv1.OperationStatusCondition{
+		Type:   webspherelibertyv1.OperationStatusConditionTypePaused,
+		Status: corev1.ConditionFalse,
+	}
+	if paused {
+		condition.Status = corev1.ConditionTrue
+		condition.Reason = StatusReasonReconcilePaused
+		condition.Message = reconcilePausedMessage
+	}
+	*conditions = webspherelibertyv1.SetOperationCondtion(*conditions, condition)
+	return paused, c.Status().Update(context.TODO(), obj)
+}
@@ -29,7 +29,9 @@ type RegisterData struct {
 }
 
 func RegisterWithOidcProvider(regData RegisterData) (string, string, error) {
-	return doRegister(regData)
+	clientId, clientSecret, err := doRegister(regData)
+	oidcRegistrationsTotal.WithLabelValues(metricsResult(err)).Inc()
+	return clientId, clientSecret, err
 }
 
 // register with oidc provider and create a new client.  return the new client id and client secret, or an error.
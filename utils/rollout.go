@@ -0,0 +1,277 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	webspherelibertyv1 "github.com/WASdev/websphere-liberty-operator/api/v1"
+	rcoutils "github.com/application-stacks/runtime-component-operator/utils"
+	routev1 "github.com/openshift/api/route/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Deployment and Service that run the image being rolled out
+const rolloutSuffix = "-rollout"
+const defaultRolloutProgressDeadlineSeconds int32 = 600
+
+// RolloutTrackLabel tells the pods of the application image apart from the pods of the image being rolled out. Both keep the
+// labels of the application, so that they are selected by its NetworkPolicy and topology spread constraints. While a new image
+// is rolled out, the PodDisruptionBudget of the application only selects the stable track.
+const RolloutTrackLabel = "liberty.websphere.ibm.com/rollout-track"
+
+const (
+	rolloutTrackStable  = "stable"
+	rolloutTrackRollout = "rollout"
+)
+
+// ingressClassNGINX is the class of the NGINX ingress controller, whose canary annotations split the traffic of an Ingress
+const ingressClassNGINX = "nginx"
+
+var defaultCanarySteps = []webspherelibertyv1.WebSphereLibertyApplicationCanaryStep{
+	{Weight: 20, Pause: &metav1.Duration{Duration: 5 * time.Minute}},
+	{Weight: 50, Pause: &metav1.Duration{Duration: 5 * time.Minute}},
+}
+
+// GetRolloutName returns the name of the Deployment, the Service and the Ingress of the image being rolled out
+func GetRolloutName(la *webspherelibertyv1.WebSphereLibertyApplication) string {
+	return la.GetName() + rolloutSuffix
+}
+
+// GetCanarySteps returns the steps of a canary rollout
+func GetCanarySteps(la *webspherelibertyv1.WebSphereLibertyApplication) []webspherelibertyv1.WebSphereLibertyApplicationCanaryStep {
+	if la.Spec.Rollout == nil || la.Spec.Rollout.Canary == nil {
+		return nil
+	}
+	if len(la.Spec.Rollout.Canary.Steps) == 0 {
+		return defaultCanarySteps
+	}
+	return la.Spec.Rollout.Canary.Steps
+}
+
+// IsRolloutInProgress returns true if the traffic is being shifted to a new image
+func IsRolloutInProgress(la *webspherelibertyv1.WebSphereLibertyApplication) bool {
+	rs := la.Status.Rollout
+	return rs != nil && (rs.Phase == webspherelibertyv1.RolloutPhaseProgressing || rs.Phase == webspherelibertyv1.RolloutPhasePromoting)
+}
+
+// GetRolloutWeight returns the percentage of the traffic sent to the image being rolled out
+func GetRolloutWeight(la *webspherelibertyv1.WebSphereLibertyApplication) int32 {
+	if !IsRolloutInProgress(la) {
+		return 0
+	}
+	return la.Status.Rollout.Weight
+}
+
+// GetRolloutReplicas returns the number of pods of the image being rolled out. A canary runs a share of the pods of the
+// application that matches the weight of its current step, until the new image is promoted.
+func GetRolloutReplicas(la *webspherelibertyv1.WebSphereLibertyApplication, replicas int32) int32 {
+	steps := GetCanarySteps(la)
+	rs := la.Status.Rollout
+	if len(steps) == 0 || rs == nil || rs.Phase == webspherelibertyv1.RolloutPhasePromoting || int(rs.Step) >= len(steps) {
+		return replicas
+	}
+	canaryReplicas := (replicas*steps[rs.Step].Weight + 99) / 100
+	if canaryReplicas < 1 {
+		return 1
+	}
+	return canaryReplicas
+}
+
+// StartRollout records the start of the rollout of a new image in the status
+func StartRollout(la *webspherelibertyv1.WebSphereLibertyApplication, image string, now metav1.Time) {
+	la.Status.Rollout = &webspherelibertyv1.RolloutStatus{
+		StableImage: la.Status.Rollout.StableImage,
+		Image:       image,
+		Phase:       webspherelibertyv1.RolloutPhaseProgressing,
+		StartTime:   &now,
+	}
+}
+
+// UpdateRolloutStatus moves the rollout forward from the readiness of the Deployment of the new image. The traffic is only shifted
+// to the new image while its pods are ready, and the rollout is aborted if they are not ready before the progress deadline.
+// Before the new image is promoted, its Deployment is scaled to the replicas of the application, and all the traffic is
+// switched to it once they are all ready.
+func UpdateRolloutStatus(la *webspherelibertyv1.WebSphereLibertyApplication, deploy *appsv1.Deployment, now metav1.Time) {
+	rs := la.Status.Rollout
+	if rs.Phase == webspherelibertyv1.RolloutPhasePromoting {
+		if IsDeploymentReady(deploy) {
+			rs.Weight = 100
+		}
+		return
+	}
+
+	if deploy.Status.ReadyReplicas == 0 {
+		rs.Weight = 0
+		rs.StepStartTime = nil
+		deadline := defaultRolloutProgressDeadlineSeconds
+		if la.Spec.Rollout.ProgressDeadlineSeconds != nil {
+			deadline = *la.Spec.Rollout.ProgressDeadlineSeconds
+		}
+		if rs.StartTime == nil || now.Sub(rs.StartTime.Time) > time.Duration(deadline)*time.Second {
+			rs.Phase = webspherelibertyv1.RolloutPhaseAborted
+		}
+		return
+	}
+
+	if rs.StepStartTime == nil {
+		rs.StepStartTime = &now
+	}
+	if steps := GetCanarySteps(la); len(steps) > 0 {
+		if int(rs.Step) < len(steps) {
+			step := steps[rs.Step]
+			rs.Weight = step.Weight
+			if step.Pause == nil || now.Sub(rs.StepStartTime.Time) >= step.Pause.Duration {
+				rs.Step++
+				rs.StepStartTime = &now
+			}
+		}
+		if int(rs.Step) >= len(steps) {
+			rs.Phase = webspherelibertyv1.RolloutPhasePromoting
+		}
+		return
+	}
+
+	rs.Weight = 0
+	if delay := la.Spec.Rollout.BlueGreen.PromotionDelay; delay == nil || now.Sub(rs.StepStartTime.Time) >= delay.Duration {
+		rs.Phase = webspherelibertyv1.RolloutPhasePromoting
+	}
+}
+
+// CustomizeStableTrack labels the pods of the application Deployment with the stable track when a rollout strategy is specified.
+// A new Deployment also selects its pods by the stable track, so that neither the Deployment nor its HorizontalPodAutoscaler
+// count the pods of the image being rolled out. The selector of an existing Deployment is immutable and is kept, so a Deployment
+// created without a rollout strategy keeps selecting the pods of both tracks.
+func CustomizeStableTrack(deploy *appsv1.Deployment, selector *metav1.LabelSelector, la *webspherelibertyv1.WebSphereLibertyApplication) {
+	rollout := la.GetRollout() != nil && la.Spec.StatefulSet == nil
+	if selector != nil {
+		deploy.Spec.Selector = selector
+	} else if rollout {
+		deploy.Spec.Selector = &metav1.LabelSelector{
+			MatchLabels: map[string]string{"app.kubernetes.io/instance": la.GetName(), RolloutTrackLabel: rolloutTrackStable},
+		}
+	}
+
+	// The pods keep matching the selector of the Deployment once the rollout strategy is removed
+	template := &deploy.Spec.Template
+	if rollout || (deploy.Spec.Selector != nil && deploy.Spec.Selector.MatchLabels[RolloutTrackLabel] == rolloutTrackStable) {
+		if template.Labels == nil {
+			template.Labels = map[string]string{}
+		}
+		template.Labels[RolloutTrackLabel] = rolloutTrackStable
+	} else {
+		delete(template.Labels, RolloutTrackLabel)
+	}
+}
+
+// IsStableTrackReady returns true if all the pods of the application Deployment are labelled with the stable track, so that a
+// new image can be rolled out without the application Service losing them
+func IsStableTrackReady(deploy *appsv1.Deployment) bool {
+	return deploy.Spec.Template.Labels[RolloutTrackLabel] == rolloutTrackStable && IsDeploymentReady(deploy)
+}
+
+// CustomizeStableService only selects the pods of the application Deployment in the application Service while a new image is
+// rolled out
+func CustomizeStableService(svc *corev1.Service, la *webspherelibertyv1.WebSphereLibertyApplication) {
+	if IsRolloutInProgress(la) {
+		if svc.Spec.Selector == nil {
+			svc.Spec.Selector = map[string]string{}
+		}
+		svc.Spec.Selector[RolloutTrackLabel] = rolloutTrackStable
+	} else {
+		delete(svc.Spec.Selector, RolloutTrackLabel)
+	}
+}
+
+// CustomizeRolloutDeployment turns a Deployment customized for the application into the Deployment of the image being rolled out.
+// Its pods keep the labels of the application, but are selected by the track label instead of the application Service.
+func CustomizeRolloutDeployment(deploy *appsv1.Deployment, la *webspherelibertyv1.WebSphereLibertyApplication, replicas int32) {
+	labels := getRolloutLabels(la)
+	deploy.Labels = rcoutils.MergeMaps(deploy.Labels, labels)
+	deploy.Spec.Replicas = &replicas
+	deploy.Spec.Selector = &metav1.LabelSelector{
+		MatchLabels: map[string]string{"app.kubernetes.io/instance": la.GetName(), RolloutTrackLabel: rolloutTrackRollout},
+	}
+	deploy.Spec.Template.Labels = rcoutils.MergeMaps(deploy.Spec.Template.Labels, labels)
+}
+
+// CustomizeRolloutService turns a Service customized for the application into the Service of the image being rolled out
+func CustomizeRolloutService(svc *corev1.Service, la *webspherelibertyv1.WebSphereLibertyApplication) {
+	svc.Labels = rcoutils.MergeMaps(svc.Labels, getRolloutLabels(la))
+	svc.Spec.Selector = map[string]string{"app.kubernetes.io/instance": la.GetName(), RolloutTrackLabel: rolloutTrackRollout}
+	// The serving certificate generated on OpenShift belongs to the application Service
+	delete(svc.Annotations, "service.beta.openshift.io/serving-cert-secret-name")
+}
+
+// CustomizeRolloutRoute splits the traffic of the Route between the application Service and the Service of the image being rolled out
+func CustomizeRolloutRoute(route *routev1.Route, la *webspherelibertyv1.WebSphereLibertyApplication) {
+	weight := GetRolloutWeight(la)
+	if weight == 0 {
+		stableWeight := int32(100)
+		route.Spec.To.Weight = &stableWeight
+		route.Spec.AlternateBackends = nil
+		return
+	}
+	stableWeight := 100 - weight
+	route.Spec.To.Weight = &stableWeight
+	route.Spec.AlternateBackends = []routev1.RouteTargetReference{
+		{Kind: "Service", Name: GetRolloutName(la), Weight: &weight},
+	}
+}
+
+// CustomizeRolloutIngress turns an Ingress customized for the application into an NGINX canary Ingress that sends a share of
+// the traffic to the Service of the image being rolled out
+func CustomizeRolloutIngress(ing *networkingv1.Ingress, la *webspherelibertyv1.WebSphereLibertyApplication) {
+	ing.Labels = rcoutils.MergeMaps(ing.Labels, getRolloutLabels(la))
+	if ing.Annotations == nil {
+		ing.Annotations = map[string]string{}
+	}
+	ing.Annotations["nginx.ingress.kubernetes.io/canary"] = "true"
+	ing.Annotations["nginx.ingress.kubernetes.io/canary-weight"] = strconv.Itoa(int(GetRolloutWeight(la)))
+
+	if ing.Spec.DefaultBackend != nil && ing.Spec.DefaultBackend.Service != nil {
+		ing.Spec.DefaultBackend.Service.Name = GetRolloutName(la)
+	}
+	for i := range ing.Spec.Rules {
+		if ing.Spec.Rules[i].HTTP == nil {
+			continue
+		}
+		for j := range ing.Spec.Rules[i].HTTP.Paths {
+			if svc := ing.Spec.Rules[i].HTTP.Paths[j].Backend.Service; svc != nil {
+				svc.Name = GetRolloutName(la)
+			}
+		}
+	}
+}
+
+// ValidateRolloutIngress returns an error if the traffic of the Ingress of the application cannot be shifted to a new image.
+// The traffic is split with the canary annotations of the NGINX ingress controller, which other ingress controllers ignore.
+func ValidateRolloutIngress(la *webspherelibertyv1.WebSphereLibertyApplication) error {
+	if la.GetRollout() == nil {
+		return nil
+	}
+	class := ""
+	if ingress := la.GetIngress(); ingress != nil {
+		if ingress.IngressClassName != nil {
+			class = *ingress.IngressClassName
+		} else {
+			class = ingress.Annotations["kubernetes.io/ingress.class"]
+		}
+	} else if route := la.GetRoute(); route != nil {
+		class = route.GetAnnotations()["kubernetes.io/ingress.class"]
+	}
+	if class != "" && class != ingressClassNGINX {
+		return fmt.Errorf("spec.rollout shifts the traffic of an Ingress with the NGINX ingress controller, but the ingress class is %s", class)
+	}
+	return nil
+}
+
+// getRolloutLabels returns the labels that distinguish the resources of the image being rolled out from the application resources
+func getRolloutLabels(la *webspherelibertyv1.WebSphereLibertyApplication) map[string]string {
+	return map[string]string{
+		RolloutTrackLabel: rolloutTrackRollout,
+	}
+}
@@ -0,0 +1,144 @@
+package utils
+
+import (
+	"strconv"
+
+	webspherelibertyv1 "github.com/WASdev/websphere-liberty-operator/api/v1"
+	rcoutils "github.com/application-stacks/runtime-component-operator/utils"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// Semeru Cloud Compiler (JITServer) deployed next to the application
+const semeruCompilerSuffix = "-semeru-compiler"
+const semeruCompilerContainerName = "compiler"
+const semeruCompilerPortName = "jitserver"
+
+// SemeruCompilerPort is the port the Semeru Cloud Compiler listens on
+const SemeruCompilerPort int32 = 38400
+
+// GetSemeruCompilerName returns the name of the Deployment and the Service of the Semeru Cloud Compiler
+func GetSemeruCompilerName(la *webspherelibertyv1.WebSphereLibertyApplication) string {
+	return la.GetName() + semeruCompilerSuffix
+}
+
+// GetSemeruCompilerHost returns the host name the application uses to connect to the Semeru Cloud Compiler
+func GetSemeruCompilerHost(la *webspherelibertyv1.WebSphereLibertyApplication) string {
+	return GetSemeruCompilerName(la) + "." + la.GetNamespace() + ".svc"
+}
+
+// getSemeruCompilerLabels returns the labels of the Semeru Cloud Compiler resources. They must not select the application pods.
+func getSemeruCompilerLabels(la *webspherelibertyv1.WebSphereLibertyApplication) map[string]string {
+	labels := la.GetLabels()
+	labels["app.kubernetes.io/instance"] = GetSemeruCompilerName(la)
+	labels["app.kubernetes.io/name"] = GetSemeruCompilerName(la)
+	labels["app.kubernetes.io/component"] = "semeru-compiler"
+	return labels
+}
+
+// getSemeruJavaOptions returns the JVM options that make the application use the Semeru Cloud Compiler
+func getSemeruJavaOptions(la *webspherelibertyv1.WebSphereLibertyApplication) string {
+	return "-XX:+UseJITServer -XX:JITServerAddress=" + GetSemeruCompilerHost(la) + " -XX:JITServerPort=" + strconv.Itoa(int(SemeruCompilerPort))
+}
+
+// CustomizeSemeruCompilerDeployment configures the Deployment of the Semeru Cloud Compiler, using the image of the application
+func CustomizeSemeruCompilerDeployment(deploy *appsv1.Deployment, la *webspherelibertyv1.WebSphereLibertyApplication) {
+	compiler := la.Spec.SemeruCloudCompiler
+	labels := getSemeruCompilerLabels(la)
+	deploy.Labels = labels
+	deploy.Annotations = rcoutils.MergeMaps(deploy.Annotations, la.GetAnnotations())
+
+	replicas := int32(1)
+	if compiler.Replicas != nil {
+		replicas = *compiler.Replicas
+	}
+	deploy.Spec.Replicas = &replicas
+	deploy.Spec.Selector = &metav1.LabelSelector{
+		MatchLabels: map[string]string{"app.kubernetes.io/instance": GetSemeruCompilerName(la)},
+	}
+
+	deploy.Spec.Template.Labels = labels
+	deploy.Spec.Template.Annotations = rcoutils.MergeMaps(deploy.Spec.Template.Annotations, la.GetAnnotations())
+	if la.Spec.ServiceAccountName != nil && *la.Spec.ServiceAccountName != "" {
+		deploy.Spec.Template.Spec.ServiceAccountName = *la.Spec.ServiceAccountName
+	} else {
+		deploy.Spec.Template.Spec.ServiceAccountName = la.GetName()
+	}
+	if la.Spec.PullSecret != nil {
+		deploy.Spec.Template.Spec.ImagePullSecrets = []corev1.LocalObjectReference{{Name: *la.Spec.PullSecret}}
+	} else {
+		deploy.Spec.Template.Spec.ImagePullSecrets = nil
+	}
+
+	resources := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("100m"),
+			corev1.ResourceMemory: resource.MustParse("800Mi"),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("2000m"),
+			corev1.ResourceMemory: resource.MustParse("1200Mi"),
+		},
+	}
+	if compiler.Resources != nil {
+		resources = *compiler.Resources
+	}
+
+	probe := &corev1.Probe{
+		Handler: corev1.Handler{
+			TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt(int(SemeruCompilerPort))},
+		},
+		InitialDelaySeconds: 10,
+		PeriodSeconds:       10,
+		FailureThreshold:    12,
+	}
+
+	deploy.Spec.Template.Spec.Containers = []corev1.Container{
+		{
+			Name:            semeruCompilerContainerName,
+			Image:           getSemeruCompilerImage(la),
+			ImagePullPolicy: *la.GetPullPolicy(),
+			Command:         []string{"jitserver"},
+			Ports: []corev1.ContainerPort{
+				{Name: semeruCompilerPortName, ContainerPort: SemeruCompilerPort, Protocol: corev1.ProtocolTCP},
+			},
+			Resources:      resources,
+			ReadinessProbe: probe,
+			LivenessProbe:  probe,
+		},
+	}
+}
+
+// getSemeruCompilerImage returns the image of the application Deployment, so that the JVM of the Semeru Cloud Compiler matches
+// the JVM of the application. While a new image is rolled out, the application Deployment keeps running the stable image.
+func getSemeruCompilerImage(la *webspherelibertyv1.WebSphereLibertyApplication) string {
+	if la.Status.Rollout != nil && la.Status.Rollout.StableImage != "" {
+		return la.Status.Rollout.StableImage
+	}
+	return la.Status.ImageReference
+}
+
+// CustomizeSemeruCompilerService configures the Service of the Semeru Cloud Compiler
+func CustomizeSemeruCompilerService(svc *corev1.Service, la *webspherelibertyv1.WebSphereLibertyApplication) {
+	svc.Labels = getSemeruCompilerLabels(la)
+	svc.Annotations = rcoutils.MergeMaps(svc.Annotations, la.GetAnnotations())
+
+	svc.Spec.Type = corev1.ServiceTypeClusterIP
+	svc.Spec.Selector = map[string]string{"app.kubernetes.io/instance": GetSemeruCompilerName(la)}
+	if len(svc.Spec.Ports) == 0 {
+		svc.Spec.Ports = append(svc.Spec.Ports, corev1.ServicePort{})
+	}
+	svc.Spec.Ports[0].Name = semeruCompilerPortName
+	svc.Spec.Ports[0].Port = SemeruCompilerPort
+	svc.Spec.Ports[0].TargetPort = intstr.FromInt(int(SemeruCompilerPort))
+	svc.Spec.Ports[0].Protocol = corev1.ProtocolTCP
+	svc.Spec.Ports = svc.Spec.Ports[:1]
+}
+
+// IsSemeruCompilerReady returns true if all the pods of the Semeru Cloud Compiler Deployment are updated and ready
+func IsSemeruCompilerReady(deploy *appsv1.Deployment) bool {
+	return IsDeploymentReady(deploy)
+}
@@ -0,0 +1,170 @@
+package utils
+
+import (
+	"strconv"
+
+	webspherelibertyv1 "github.com/WASdev/websphere-liberty-operator/api/v1"
+	rcoutils "github.com/application-stacks/runtime-component-operator/utils"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Istio service mesh integration
+// PERMISSIVE is the default of Istio, so that the clients outside the mesh, e.g. the ingress controller or Prometheus, keep
+// reaching the application
+const defaultServiceMeshMTLSMode = "PERMISSIVE"
+const serviceMeshMeshGateway = "mesh"
+
+// serviceMeshPodAnnotations inject the Istio sidecar in the application pods. Kubelet probes are rewritten to be sent by the
+// sidecar, so that they pass with STRICT mutual TLS, and the Liberty container only starts once the sidecar is ready, so that
+// the startup probe does not fail while the sidecar is starting.
+var serviceMeshPodAnnotations = map[string]string{
+	"sidecar.istio.io/inject":                "true",
+	"sidecar.istio.io/rewriteAppHTTPProbers": "true",
+	"proxy.istio.io/config":                  "holdApplicationUntilProxyStarts: true",
+}
+
+// VirtualServiceGroupVersionKind identifies the Istio VirtualServices that route the traffic to the application
+var VirtualServiceGroupVersionKind = schema.GroupVersionKind{Group: "networking.istio.io", Version: "v1beta1", Kind: "VirtualService"}
+
+// DestinationRuleGroupVersionKind identifies the Istio DestinationRules that apply to the traffic to the application
+var DestinationRuleGroupVersionKind = schema.GroupVersionKind{Group: "networking.istio.io", Version: "v1beta1", Kind: "DestinationRule"}
+
+// PeerAuthenticationGroupVersionKind identifies the Istio PeerAuthentications of the application pods
+var PeerAuthenticationGroupVersionKind = schema.GroupVersionKind{Group: "security.istio.io", Version: "v1beta1", Kind: "PeerAuthentication"}
+
+// getServiceMeshMTLSMode returns the mutual TLS mode of the traffic to the application pods
+func getServiceMeshMTLSMode(la *webspherelibertyv1.WebSphereLibertyApplication) string {
+	if mode := la.GetServiceMesh().MTLSMode; mode != "" {
+		return mode
+	}
+	return defaultServiceMeshMTLSMode
+}
+
+// CustomizeServiceMesh injects the Istio sidecar in the application pods, or removes the annotations that inject it when the
+// service mesh is disabled, unless they are set on the application
+func CustomizeServiceMesh(pts *corev1.PodTemplateSpec, la *webspherelibertyv1.WebSphereLibertyApplication) {
+	if la.GetServiceMesh() != nil {
+		pts.Annotations = rcoutils.MergeMaps(pts.Annotations, serviceMeshPodAnnotations)
+		return
+	}
+	annotations := la.GetAnnotations()
+	for key := range serviceMeshPodAnnotations {
+		if _, ok := annotations[key]; !ok {
+			delete(pts.Annotations, key)
+		}
+	}
+}
+
+// CustomizeVirtualService routes the traffic for the application Service, and for the additional hosts, to the application.
+// The application is exposed to the Istio Gateways as well as to the sidecars of the mesh. While an image is rolled out, the
+// traffic is split with the Service of the new image. HTTPS traffic is routed on its SNI host, since the sidecars do not
+// terminate the TLS of Liberty.
+func CustomizeVirtualService(vs *unstructured.Unstructured, la *webspherelibertyv1.WebSphereLibertyApplication) error {
+	sm := la.GetServiceMesh()
+	vs.SetLabels(rcoutils.MergeMaps(vs.GetLabels(), la.GetLabels()))
+	vs.SetAnnotations(rcoutils.MergeMaps(vs.GetAnnotations(), la.GetAnnotations()))
+
+	hosts := []interface{}{la.GetName()}
+	for _, host := range sm.Hosts {
+		hosts = append(hosts, host)
+	}
+	spec := map[string]interface{}{"hosts": hosts}
+	if len(sm.Gateways) > 0 {
+		gateways := []interface{}{serviceMeshMeshGateway}
+		for _, gateway := range sm.Gateways {
+			gateways = append(gateways, gateway)
+		}
+		spec["gateways"] = gateways
+	}
+
+	port := int64(la.Spec.Service.GetPort())
+	weight := int64(GetRolloutWeight(la))
+	destinations := []interface{}{
+		map[string]interface{}{"destination": map[string]interface{}{"host": la.GetName(), "port": map[string]interface{}{"number": port}}, "weight": 100 - weight},
+	}
+	if weight > 0 {
+		destinations = append(destinations, map[string]interface{}{"destination": map[string]interface{}{"host": GetRolloutName(la), "port": map[string]interface{}{"number": port}}, "weight": weight})
+	}
+
+	if la.Spec.Service != nil && la.Spec.Service.CertificateSecretRef != nil {
+		spec["tls"] = []interface{}{
+			map[string]interface{}{
+				"match": []interface{}{map[string]interface{}{"port": port, "sniHosts": hosts}},
+				"route": destinations,
+			},
+		}
+	} else {
+		spec["http"] = []interface{}{
+			map[string]interface{}{"route": destinations},
+		}
+	}
+	return unstructured.SetNestedField(vs.Object, spec, "spec")
+}
+
+// CustomizeDestinationRule applies the mutual TLS mode and the connection pool limits to the traffic to the application
+func CustomizeDestinationRule(dr *unstructured.Unstructured, la *webspherelibertyv1.WebSphereLibertyApplication) error {
+	sm := la.GetServiceMesh()
+	dr.SetLabels(rcoutils.MergeMaps(dr.GetLabels(), la.GetLabels()))
+	dr.SetAnnotations(rcoutils.MergeMaps(dr.GetAnnotations(), la.GetAnnotations()))
+
+	tlsMode := "ISTIO_MUTUAL"
+	if getServiceMeshMTLSMode(la) == "DISABLE" {
+		tlsMode = "DISABLE"
+	}
+	trafficPolicy := map[string]interface{}{
+		"tls": map[string]interface{}{"mode": tlsMode},
+	}
+
+	if cp := sm.ConnectionPool; cp != nil {
+		tcp := map[string]interface{}{}
+		if cp.MaxConnections != nil {
+			tcp["maxConnections"] = int64(*cp.MaxConnections)
+		}
+		if cp.ConnectTimeout != nil {
+			tcp["connectTimeout"] = strconv.FormatFloat(cp.ConnectTimeout.Seconds(), 'f', -1, 64) + "s"
+		}
+		http := map[string]interface{}{}
+		if cp.MaxPendingRequests != nil {
+			http["http1MaxPendingRequests"] = int64(*cp.MaxPendingRequests)
+		}
+		if cp.MaxRequests != nil {
+			http["http2MaxRequests"] = int64(*cp.MaxRequests)
+		}
+		if cp.MaxRequestsPerConnection != nil {
+			http["maxRequestsPerConnection"] = int64(*cp.MaxRequestsPerConnection)
+		}
+		connectionPool := map[string]interface{}{}
+		if len(tcp) > 0 {
+			connectionPool["tcp"] = tcp
+		}
+		if len(http) > 0 {
+			connectionPool["http"] = http
+		}
+		if len(connectionPool) > 0 {
+			trafficPolicy["connectionPool"] = connectionPool
+		}
+	}
+
+	spec := map[string]interface{}{
+		"host":          la.GetName(),
+		"trafficPolicy": trafficPolicy,
+	}
+	return unstructured.SetNestedField(dr.Object, spec, "spec")
+}
+
+// CustomizePeerAuthentication applies the mutual TLS mode to the traffic received by the application pods, including the pods
+// of an image being rolled out
+func CustomizePeerAuthentication(pa *unstructured.Unstructured, la *webspherelibertyv1.WebSphereLibertyApplication) error {
+	pa.SetLabels(rcoutils.MergeMaps(pa.GetLabels(), la.GetLabels()))
+	pa.SetAnnotations(rcoutils.MergeMaps(pa.GetAnnotations(), la.GetAnnotations()))
+
+	spec := map[string]interface{}{
+		"selector": map[string]interface{}{
+			"matchLabels": map[string]interface{}{"app.kubernetes.io/instance": la.GetName()},
+		},
+		"mtls": map[string]interface{}{"mode": getServiceMeshMTLSMode(la)},
+	}
+	return unstructured.SetNestedField(pa.Object, spec, "spec")
+}
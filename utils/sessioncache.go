@@ -0,0 +1,248 @@
+package utils
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"strings"
+
+	webspherelibertyv1 "github.com/WASdev/websphere-liberty-operator/api/v1"
+	rcoutils "github.com/application-stacks/runtime-component-operator/utils"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// HTTP session caching with JCache
+const sessionCacheSuffix = "-session-cache"
+const sessionCacheCredentialsSuffix = "-session-cache-credentials"
+const sessionCacheInfinispanSuffix = "-infinispan"
+const sessionCacheVolume = "session-cache"
+const sessionCacheConfigFile = "session-cache.xml"
+const sessionCacheHazelcastFile = "hazelcast-client.xml"
+const sessionCacheInfinispanPort = "11222"
+
+// sessionCacheSerialAllowList is the list of the classes that the Infinispan client deserializes from the cache. Liberty stores
+// the attributes of the sessions as byte arrays, and their metadata as lists of strings and numbers, so no other class is read.
+const sessionCacheSerialAllowList = `java\.lang\.(String|Boolean|Number|Short|Integer|Long),java\.util\.(ArrayList|HashSet|TreeSet),\[B`
+
+// InfinispanGroupVersionKind identifies the Infinispan clusters managed by the Infinispan Operator
+var InfinispanGroupVersionKind = schema.GroupVersionKind{Group: "infinispan.org", Version: "v1", Kind: "Infinispan"}
+
+// GetSessionCacheConfigMapName returns the name of the ConfigMap with the Liberty session cache configuration
+func GetSessionCacheConfigMapName(la *webspherelibertyv1.WebSphereLibertyApplication) string {
+	return la.GetName() + sessionCacheSuffix
+}
+
+// GetSessionCacheGeneratedCredentialsName returns the name of the Secret with the credentials generated for a provisioned Infinispan cluster
+func GetSessionCacheGeneratedCredentialsName(la *webspherelibertyv1.WebSphereLibertyApplication) string {
+	return la.GetName() + sessionCacheCredentialsSuffix
+}
+
+// GetSessionCacheInfinispanName returns the name of the Infinispan cluster provisioned for the application
+func GetSessionCacheInfinispanName(la *webspherelibertyv1.WebSphereLibertyApplication) string {
+	return la.GetName() + sessionCacheInfinispanSuffix
+}
+
+// IsSessionCacheProvisioned returns true if the operator provisions an Infinispan cluster for the application
+func IsSessionCacheProvisioned(la *webspherelibertyv1.WebSphereLibertyApplication) bool {
+	sc := la.GetSessionCache()
+	return sc != nil && sc.Provision && sc.Provider == webspherelibertyv1.SessionCacheProviderInfinispan
+}
+
+// getSessionCacheCredentialsName returns the name of the Secret with the credentials of the cache cluster, or an empty string if there are none
+func getSessionCacheCredentialsName(la *webspherelibertyv1.WebSphereLibertyApplication) string {
+	sc := la.GetSessionCache()
+	if IsSessionCacheProvisioned(la) {
+		return GetSessionCacheGeneratedCredentialsName(la)
+	}
+	if sc.CredentialsSecretRef != nil {
+		return *sc.CredentialsSecretRef
+	}
+	return ""
+}
+
+// getSessionCacheServerList returns the addresses of the cache servers
+func getSessionCacheServerList(la *webspherelibertyv1.WebSphereLibertyApplication) string {
+	sc := la.GetSessionCache()
+	if sc.ServerList != "" {
+		return sc.ServerList
+	}
+	return GetSessionCacheInfinispanName(la) + "." + la.GetNamespace() + ".svc:" + sessionCacheInfinispanPort
+}
+
+// CustomizeSessionCacheCredentials generates the credentials of the provisioned Infinispan cluster, unless they already exist.
+// The identities.yaml key is the format the Infinispan Operator expects for endpoint credentials.
+func CustomizeSessionCacheCredentials(secret *corev1.Secret, la *webspherelibertyv1.WebSphereLibertyApplication) error {
+	secret.Labels = la.GetLabels()
+	secret.Annotations = rcoutils.MergeMaps(secret.Annotations, la.GetAnnotations())
+
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	if len(secret.Data["username"]) == 0 {
+		secret.Data["username"] = []byte("liberty")
+	}
+	if len(secret.Data["password"]) == 0 {
+		password := make([]byte, 24)
+		if _, err := rand.Read(password); err != nil {
+			return err
+		}
+		secret.Data["password"] = []byte(base64.RawURLEncoding.EncodeToString(password))
+	}
+	secret.Data["identities.yaml"] = []byte("credentials:\n" +
+		"- username: " + string(secret.Data["username"]) + "\n" +
+		"  password: " + string(secret.Data["password"]) + "\n")
+	return nil
+}
+
+// CustomizeSessionCacheInfinispan configures the Infinispan cluster provisioned for the application
+func CustomizeSessionCacheInfinispan(infinispan *unstructured.Unstructured, la *webspherelibertyv1.WebSphereLibertyApplication) error {
+	infinispan.SetLabels(la.GetLabels())
+	infinispan.SetAnnotations(rcoutils.MergeMaps(infinispan.GetAnnotations(), la.GetAnnotations()))
+
+	replicas := int64(1)
+	if la.GetSessionCache().Replicas != nil {
+		replicas = int64(*la.GetSessionCache().Replicas)
+	}
+	if err := unstructured.SetNestedField(infinispan.Object, replicas, "spec", "replicas"); err != nil {
+		return err
+	}
+	if err := unstructured.SetNestedField(infinispan.Object, "Cache", "spec", "service", "type"); err != nil {
+		return err
+	}
+	return unstructured.SetNestedField(infinispan.Object, GetSessionCacheGeneratedCredentialsName(la), "spec", "security", "endpointSecretName")
+}
+
+// CustomizeSessionCacheConfigMap generates the Liberty configuration that stores HTTP sessions in the cache cluster.
+// Credentials are read from environment variables set by CustomizeSessionCache.
+func CustomizeSessionCacheConfigMap(cm *corev1.ConfigMap, la *webspherelibertyv1.WebSphereLibertyApplication) {
+	cm.Labels = la.GetLabels()
+	cm.Annotations = rcoutils.MergeMaps(cm.Annotations, la.GetAnnotations())
+
+	sc := la.GetSessionCache()
+	if sc.Provider == webspherelibertyv1.SessionCacheProviderHazelcast {
+		clusterName := sc.ClusterName
+		if clusterName == "" {
+			clusterName = "dev"
+		}
+		var members strings.Builder
+		for _, address := range strings.Split(sc.ServerList, ",") {
+			if address = strings.TrimSpace(address); address != "" {
+				members.WriteString("      <address>" + address + "</address>\n")
+			}
+		}
+		cm.Data = map[string]string{
+			sessionCacheConfigFile: "<server>\n" +
+				"  <featureManager>\n" +
+				"    <feature>sessionCache-1.0</feature>\n" +
+				"  </featureManager>\n" +
+				"  <httpSessionCache libraryRef=\"HazelcastLib\" uri=\"file:${server.config.dir}/" + sessionCacheHazelcastFile + "\"/>\n" +
+				"  <library id=\"HazelcastLib\">\n" +
+				"    <file name=\"${shared.resource.dir}/hazelcast/hazelcast.jar\"/>\n" +
+				"  </library>\n" +
+				"</server>\n",
+			sessionCacheHazelcastFile: "<hazelcast-client xmlns=\"http://www.hazelcast.com/schema/client-config\">\n" +
+				"  <cluster-name>" + clusterName + "</cluster-name>\n" +
+				"  <network>\n" +
+				"    <cluster-members>\n" +
+				members.String() +
+				"    </cluster-members>\n" +
+				"  </network>\n" +
+				"</hazelcast-client>\n",
+		}
+		return
+	}
+
+	auth := ""
+	if getSessionCacheCredentialsName(la) != "" {
+		auth = "\n      infinispan.client.hotrod.auth_username=\"${env.SESSION_CACHE_USERNAME}\"" +
+			"\n      infinispan.client.hotrod.auth_password=\"${env.SESSION_CACHE_PASSWORD}\"" +
+			"\n      infinispan.client.hotrod.auth_realm=\"default\"" +
+			"\n      infinispan.client.hotrod.sasl_mechanism=\"DIGEST-MD5\""
+	}
+	cm.Data = map[string]string{
+		sessionCacheConfigFile: "<server>\n" +
+			"  <featureManager>\n" +
+			"    <feature>sessionCache-1.0</feature>\n" +
+			"  </featureManager>\n" +
+			"  <httpSessionCache libraryRef=\"InfinispanLib\">\n" +
+			"    <properties infinispan.client.hotrod.server_list=\"" + strings.ReplaceAll(getSessionCacheServerList(la), ",", ";") + "\"" + auth +
+			"\n      infinispan.client.hotrod.marshaller=\"org.infinispan.commons.marshall.JavaSerializationMarshaller\"" +
+			"\n      infinispan.client.hotrod.java_serial_whitelist=\"" + sessionCacheSerialAllowList + "\"/>\n" +
+			"  </httpSessionCache>\n" +
+			"  <library id=\"InfinispanLib\">\n" +
+			"    <fileset dir=\"${shared.resource.dir}/infinispan\" includes=\"*.jar\"/>\n" +
+			"  </library>\n" +
+			"</server>\n",
+	}
+}
+
+// CustomizeSessionCache mounts the session cache configuration into the pods, sets the credentials of the cache cluster
+// and records the revision of the configuration so that pods are rolled when it changes
+func CustomizeSessionCache(pts *corev1.PodTemplateSpec, la *webspherelibertyv1.WebSphereLibertyApplication, client client.Client) error {
+	if la.GetSessionCache() == nil {
+		return nil
+	}
+
+	cm := &corev1.ConfigMap{}
+	err := client.Get(context.TODO(), types.NamespacedName{Name: GetSessionCacheConfigMapName(la), Namespace: la.GetNamespace()}, cm)
+	if err != nil {
+		return errors.Wrapf(err, "ConfigMap %q for the session cache configuration was not found in namespace %q", GetSessionCacheConfigMapName(la), la.GetNamespace())
+	}
+
+	mounts := []corev1.VolumeMount{
+		{Name: sessionCacheVolume, MountPath: serverConfigOverridesDir + "/" + sessionCacheConfigFile, SubPath: sessionCacheConfigFile, ReadOnly: true},
+	}
+	if _, ok := cm.Data[sessionCacheHazelcastFile]; ok {
+		mounts = append(mounts, corev1.VolumeMount{Name: sessionCacheVolume, MountPath: "/config/" + sessionCacheHazelcastFile, SubPath: sessionCacheHazelcastFile, ReadOnly: true})
+	}
+	for _, mount := range mounts {
+		foundVolumeMount := false
+		for i, vm := range pts.Spec.Containers[0].VolumeMounts {
+			if vm.MountPath == mount.MountPath {
+				pts.Spec.Containers[0].VolumeMounts[i] = mount
+				foundVolumeMount = true
+			}
+		}
+		if !foundVolumeMount {
+			pts.Spec.Containers[0].VolumeMounts = append(pts.Spec.Containers[0].VolumeMounts, mount)
+		}
+	}
+
+	foundVolume := false
+	for _, v := range pts.Spec.Volumes {
+		if v.Name == sessionCacheVolume {
+			foundVolume = true
+		}
+	}
+	if !foundVolume {
+		pts.Spec.Volumes = append(pts.Spec.Volumes, corev1.Volume{
+			Name: sessionCacheVolume,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: cm.Name}},
+			},
+		})
+	}
+
+	targetEnv := []corev1.EnvVar{{Name: "SESSION_CACHE_REV", Value: cm.ResourceVersion}}
+	if credentials := getSessionCacheCredentialsName(la); credentials != "" {
+		targetEnv = append(targetEnv,
+			corev1.EnvVar{Name: "SESSION_CACHE_USERNAME", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: credentials}, Key: "username"}}},
+			corev1.EnvVar{Name: "SESSION_CACHE_PASSWORD", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: credentials}, Key: "password"}}},
+		)
+	}
+	for _, v := range targetEnv {
+		if env, found := findEnvVar(v.Name, pts.Spec.Containers[0].Env); found {
+			*env = v
+		} else {
+			pts.Spec.Containers[0].Env = append(pts.Spec.Containers[0].Env, v)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,170 @@
+package utils
+
+import (
+	"fmt"
+
+	webspherelibertyv1 "github.com/WASdev/websphere-liberty-operator/api/v1"
+	routev1 "github.com/openshift/api/route/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
+)
+
+// Reasons of the ResourcesReady and Ready status conditions
+const (
+	StatusReasonResourcesNotReady = "ResourcesNotReady"
+	StatusReasonReconcileFailed   = "ReconcileFailed"
+)
+
+// StatusReasonLicenseNotAccepted is the reason of the LicenseAccepted condition while spec.license.accept is not true
+const StatusReasonLicenseNotAccepted = "LicenseNotAccepted"
+
+// GetRouteEndpoint returns the URL of the application exposed by a Route, or an empty string if the Route has no host yet
+func GetRouteEndpoint(route *routev1.Route) string {
+	if route.Spec.Host == "" {
+		return ""
+	}
+	scheme := "http"
+	if route.Spec.TLS != nil {
+		scheme = "https"
+	}
+	return scheme + "://" + route.Spec.Host + route.Spec.Path
+}
+
+// GetIngressEndpoint returns the URL of the application exposed by the first rule of an Ingress, or an empty string if the
+// Ingress has no host
+func GetIngressEndpoint(ing *networkingv1.Ingress) string {
+	if len(ing.Spec.Rules) == 0 || ing.Spec.Rules[0].Host == "" {
+		return ""
+	}
+	rule := ing.Spec.Rules[0]
+	scheme := "http"
+	for _, tls := range ing.Spec.TLS {
+		for _, host := range tls.Hosts {
+			if host == rule.Host {
+				scheme = "https"
+			}
+		}
+	}
+	path := ""
+	if rule.HTTP != nil && len(rule.HTTP.Paths) > 0 && rule.HTTP.Paths[0].Path != "/" {
+		path = rule.HTTP.Paths[0].Path
+	}
+	return scheme + "://" + rule.Host + path
+}
+
+// GetKnativeServiceEndpoint returns the URL of the application exposed by a Knative Service once Knative assigned it
+func GetKnativeServiceEndpoint(ksvc *servingv1.Service) string {
+	if ksvc.Status.URL == nil {
+		return ""
+	}
+	return ksvc.Status.URL.String()
+}
+
+// IsDeploymentReady returns true if all the pods of a Deployment are updated and ready
+func IsDeploymentReady(deploy *appsv1.Deployment) bool {
+	if deploy.Spec.Replicas == nil || deploy.Status.ObservedGeneration < deploy.Generation {
+		return false
+	}
+	return deploy.Status.UpdatedReplicas == *deploy.Spec.Replicas && deploy.Status.ReadyReplicas == *deploy.Spec.Replicas
+}
+
+// IsStatefulSetReady returns true if all the pods of a StatefulSet are updated and ready
+func IsStatefulSetReady(statefulSet *appsv1.StatefulSet) bool {
+	if statefulSet.Spec.Replicas == nil || statefulSet.Status.ObservedGeneration < statefulSet.Generation {
+		return false
+	}
+	return statefulSet.Status.UpdatedReplicas == *statefulSet.Spec.Replicas && statefulSet.Status.ReadyReplicas == *statefulSet.Spec.Replicas
+}
+
+// IsKnativeServiceReady returns true if the Ready condition of a Knative Service is true for its latest generation
+func IsKnativeServiceReady(ksvc *servingv1.Service) bool {
+	if ksvc.Status.ObservedGeneration < ksvc.Generation {
+		return false
+	}
+	for _, c := range ksvc.Status.Conditions {
+		if c.Type == "Ready" {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// SetDeploymentStatus reports the replica counts and the readiness of the Deployment of the application in its status
+func SetDeploymentStatus(la *webspherelibertyv1.WebSphereLibertyApplication, deploy *appsv1.Deployment) {
+	setReplicasStatus(la, deploy.Spec.Replicas, deploy.Status.ReadyReplicas, IsDeploymentReady(deploy), "Deployment")
+}
+
+// SetStatefulSetStatus reports the replica counts and the readiness of the StatefulSet of the application in its status
+func SetStatefulSetStatus(la *webspherelibertyv1.WebSphereLibertyApplication, statefulSet *appsv1.StatefulSet) {
+	setReplicasStatus(la, statefulSet.Spec.Replicas, statefulSet.Status.ReadyReplicas, IsStatefulSetReady(statefulSet), "StatefulSet")
+}
+
+// SetKnativeServiceStatus reports the readiness of the Knative Service of the application in its status. Knative scales
+// the pods of the application, so no replica counts are reported.
+func SetKnativeServiceStatus(la *webspherelibertyv1.WebSphereLibertyApplication, ksvc *servingv1.Service) {
+	la.Status.Replicas = 0
+	la.Status.ReadyReplicas = 0
+	if IsKnativeServiceReady(ksvc) {
+		SetStatusCondition(la, webspherelibertyv1.StatusConditionTypeResourcesReady, corev1.ConditionTrue, "", "")
+	} else {
+		SetStatusCondition(la, webspherelibertyv1.StatusConditionTypeResourcesReady, corev1.ConditionFalse, StatusReasonResourcesNotReady,
+			"Waiting for the Knative Service to be ready")
+	}
+}
+
+func setReplicasStatus(la *webspherelibertyv1.WebSphereLibertyApplication, replicas *int32, readyReplicas int32, ready bool, kind string) {
+	la.Status.Replicas = 1
+	if replicas != nil {
+		la.Status.Replicas = *replicas
+	}
+	la.Status.ReadyReplicas = readyReplicas
+	if ready {
+		SetStatusCondition(la, webspherelibertyv1.StatusConditionTypeResourcesReady, corev1.ConditionTrue, "", "")
+	} else {
+		SetStatusCondition(la, webspherelibertyv1.StatusConditionTypeResourcesReady, corev1.ConditionFalse, StatusReasonResourcesNotReady,
+			fmt.Sprintf("%d/%d pods of the %s are ready", readyReplicas, la.Status.Replicas, kind))
+	}
+}
+
+// SetStatusCondition sets a condition in the status of the application
+func SetStatusCondition(la *webspherelibertyv1.WebSphereLibertyApplication, t webspherelibertyv1.StatusConditionType, status corev1.ConditionStatus, reason string, message string) {
+	la.Status.SetCondition(&webspherelibertyv1.StatusCondition{
+		Type:    t,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	})
+}
+
+// SetReadyCondition sets the Ready condition of a successfully reconciled application from its ResourcesReady condition
+func SetReadyCondition(la *webspherelibertyv1.WebSphereLibertyApplication) {
+	resourcesReady := getStatusCondition(la, webspherelibertyv1.StatusConditionTypeResourcesReady)
+	if resourcesReady == nil {
+		SetStatusCondition(la, webspherelibertyv1.StatusConditionTypeReady, corev1.ConditionFalse, StatusReasonResourcesNotReady, "")
+	} else if resourcesReady.Status != corev1.ConditionTrue {
+		SetStatusCondition(la, webspherelibertyv1.StatusConditionTypeReady, corev1.ConditionFalse, resourcesReady.Reason, resourcesReady.Message)
+	} else {
+		SetStatusCondition(la, webspherelibertyv1.StatusConditionTypeReady, corev1.ConditionTrue, "", "")
+	}
+}
+
+// SetLicenseCondition reports in the LicenseAccepted condition whether the license is accepted. The condition is only set to
+// True once it has been reported as False, so that it only shows up for the applications that do not accept the license.
+func SetLicenseCondition(la *webspherelibertyv1.WebSphereLibertyApplication) {
+	if err := la.ValidateLicense(); err != nil {
+		SetStatusCondition(la, webspherelibertyv1.StatusConditionTypeLicenseAccepted, corev1.ConditionFalse, StatusReasonLicenseNotAccepted, err.Error())
+	} else if getStatusCondition(la, webspherelibertyv1.StatusConditionTypeLicenseAccepted) != nil {
+		SetStatusCondition(la, webspherelibertyv1.StatusConditionTypeLicenseAccepted, corev1.ConditionTrue, "", "")
+	}
+}
+
+func getStatusCondition(la *webspherelibertyv1.WebSphereLibertyApplication, t webspherelibertyv1.StatusConditionType) *webspherelibertyv1.StatusCondition {
+	for i := range la.Status.Conditions {
+		if la.Status.Conditions[i].Type == t {
+			return &la.Status.Conditions[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+package utils
+
+import (
+	"strconv"
+
+	webspherelibertyv1 "github.com/WASdev/websphere-liberty-operator/api/v1"
+	rcoutils "github.com/application-stacks/runtime-component-operator/utils"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Transaction log persistence and recovery for StatefulSets
+const transactionRecoverySuffix = "-transaction-recovery"
+const transactionRecoveryJobSuffix = "-tx-recovery-"
+const transactionRecoveryVolume = "transaction-recovery"
+const transactionRecoveryConfigFile = "transaction-recovery.xml"
+const transactionLogSubPath = "tranlog"
+const transactionLogMountPath = "/tranlog"
+const transactionRecoveryGroupEnv = "TX_RECOVERY_GROUP"
+const transactionRecoveryIdentityEnv = "TX_RECOVERY_IDENTITY"
+
+// IsTransactionRecoveryEnabled returns true if the transaction logs of the StatefulSet pods are persisted and recovered on scale down
+func IsTransactionRecoveryEnabled(la *webspherelibertyv1.WebSphereLibertyApplication) bool {
+	return la.Spec.StatefulSet != nil && la.Spec.StatefulSet.GetTransactionRecovery() != nil
+}
+
+// GetTransactionRecoveryConfigMapName returns the name of the ConfigMap with the Liberty transaction recovery configuration
+func GetTransactionRecoveryConfigMapName(la *webspherelibertyv1.WebSphereLibertyApplication) string {
+	return la.GetName() + transactionRecoverySuffix
+}
+
+// GetTransactionRecoveryJobName returns the name of the Job that recovers the transactions of the StatefulSet pod with the given ordinal
+func GetTransactionRecoveryJobName(la *webspherelibertyv1.WebSphereLibertyApplication, ordinal int32) string {
+	return la.GetName() + transactionRecoveryJobSuffix + strconv.Itoa(int(ordinal))
+}
+
+// getTransactionRecoveryGroup returns the Liberty recovery group of the pods
+func getTransactionRecoveryGroup(la *webspherelibertyv1.WebSphereLibertyApplication) string {
+	if group := la.Spec.StatefulSet.GetTransactionRecovery().RecoveryGroup; group != "" {
+		return group
+	}
+	return la.GetName()
+}
+
+// CustomizeTransactionRecoveryConfigMap generates the Liberty transaction configuration. The recovery group and identity are
+// read from environment variables, so that the recovery Job can take over the identity of a removed pod.
+func CustomizeTransactionRecoveryConfigMap(cm *corev1.ConfigMap, la *webspherelibertyv1.WebSphereLibertyApplication) {
+	cm.Labels = la.GetLabels()
+	cm.Annotations = rcoutils.MergeMaps(cm.Annotations, la.GetAnnotations())
+	cm.Data = map[string]string{
+		transactionRecoveryConfigFile: "<server>\n" +
+			"  <transaction transactionLogDirectory=\"" + transactionLogMountPath + "/\"\n" +
+			"    recoveryGroup=\"${env." + transactionRecoveryGroupEnv + "}\"\n" +
+			"    recoveryIdentity=\"${env." + transactionRecoveryIdentityEnv + "}\"\n" +
+			"    waitForRecovery=\"true\"/>\n" +
+			"</server>\n",
+	}
+}
+
+// CustomizeTransactionRecovery mounts the transaction log directory from the persisted storage of each StatefulSet pod and
+// uses the name of the pod, which is stable across restarts, as its Liberty recovery identity. It must be called after
+// CustomizePersistence.
+func CustomizeTransactionRecovery(statefulSet *appsv1.StatefulSet, la *webspherelibertyv1.WebSphereLibertyApplication) {
+	if !IsTransactionRecoveryEnabled(la) || len(statefulSet.Spec.VolumeClaimTemplates) == 0 {
+		return
+	}
+	pts := &statefulSet.Spec.Template
+
+	mounts := []corev1.VolumeMount{
+		{Name: statefulSet.Spec.VolumeClaimTemplates[0].Name, MountPath: transactionLogMountPath, SubPath: transactionLogSubPath},
+		{Name: transactionRecoveryVolume, MountPath: serverConfigOverridesDir + "/" + transactionRecoveryConfigFile, SubPath: transactionRecoveryConfigFile, ReadOnly: true},
+	}
+	for _, mount := range mounts {
+		foundVolumeMount := false
+		for i, vm := range pts.Spec.Containers[0].VolumeMounts {
+			if vm.MountPath == mount.MountPath {
+				pts.Spec.Containers[0].VolumeMounts[i] = mount
+				foundVolumeMount = true
+			}
+		}
+		if !foundVolumeMount {
+			pts.Spec.Containers[0].VolumeMounts = append(pts.Spec.Containers[0].VolumeMounts, mount)
+		}
+	}
+
+	foundVolume := false
+	for _, v := range pts.Spec.Volumes {
+		if v.Name == transactionRecoveryVolume {
+			foundVolume = true
+		}
+	}
+	if !foundVolume {
+		pts.Spec.Volumes = append(pts.Spec.Volumes, corev1.Volume{
+			Name: transactionRecoveryVolume,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: GetTransactionRecoveryConfigMapName(la)}},
+			},
+		})
+	}
+
+	targetEnv := []corev1.EnvVar{
+		{Name: transactionRecoveryGroupEnv, Value: getTransactionRecoveryGroup(la)},
+		{Name: transactionRecoveryIdentityEnv, ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.name"}}},
+	}
+	for _, v := range targetEnv {
+		if env, found := findEnvVar(v.Name, pts.Spec.Containers[0].Env); found {
+			*env = v
+		} else {
+			pts.Spec.Containers[0].Env = append(pts.Spec.Containers[0].Env, v)
+		}
+	}
+}
+
+// CustomizeTransactionRecoveryJob configures a Job that acts as the peer of a StatefulSet pod removed on scale down. It starts
+// the Liberty server of the application with the persisted storage and the recovery identity of the removed pod, waits for
+// the in-doubt transactions to be recovered, and stops the server.
+func CustomizeTransactionRecoveryJob(job *batchv1.Job, statefulSet *appsv1.StatefulSet, la *webspherelibertyv1.WebSphereLibertyApplication, ordinal int32) {
+	labels := la.GetLabels()
+	labels["app.kubernetes.io/instance"] = job.Name
+	labels["app.kubernetes.io/component"] = "transaction-recovery"
+	job.Labels = labels
+	job.Annotations = rcoutils.MergeMaps(job.Annotations, la.GetAnnotations())
+
+	podName := statefulSet.Name + "-" + strconv.Itoa(int(ordinal))
+	pts := statefulSet.Spec.Template.DeepCopy()
+	pts.Labels = labels
+	pts.Spec.RestartPolicy = corev1.RestartPolicyOnFailure
+
+	container := pts.Spec.Containers[0]
+	container.Command = []string{"/bin/sh", "-c", "/opt/ibm/wlp/bin/server start && /opt/ibm/wlp/bin/server stop"}
+	container.Args = nil
+	container.Ports = nil
+	container.LivenessProbe = nil
+	container.ReadinessProbe = nil
+	container.StartupProbe = nil
+	if env, found := findEnvVar(transactionRecoveryIdentityEnv, container.Env); found {
+		*env = corev1.EnvVar{Name: transactionRecoveryIdentityEnv, Value: podName}
+	}
+	// Sidecars would keep the Job from completing
+	pts.Spec.Containers = []corev1.Container{container}
+
+	for _, claim := range statefulSet.Spec.VolumeClaimTemplates {
+		pts.Spec.Volumes = append(pts.Spec.Volumes, corev1.Volume{
+			Name: claim.Name,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: claim.Name + "-" + podName},
+			},
+		})
+	}
+	job.Spec.Template = *pts
+}
+
+// IsTransactionRecoveryJobComplete returns true if the Job has recovered the transactions
+func IsTransactionRecoveryJobComplete(job *batchv1.Job) bool {
+	for _, c := range job.Status.Conditions {
+		if c.Type == batchv1.JobComplete && c.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+// IsTransactionRecoveryJobFailed returns true if the Job has reached its backoff limit without recovering the transactions
+func IsTransactionRecoveryJobFailed(job *batchv1.Job) bool {
+	for _, c := range job.Status.Conditions {
+		if c.Type == batchv1.JobFailed && c.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
@@ -13,9 +13,12 @@ import (
 	routev1 "github.com/openshift/api/route/v1"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
@@ -32,34 +35,39 @@ var log = logf.Log.WithName("websphereliberty_utils")
 //Constant Values
 const serviceabilityMountPath = "/serviceability"
 const ssoEnvVarPrefix = "SEC_SSO_"
+const serverConfigOverridesDir = "/config/configDropins/overrides"
+const serverConfigDefaultsDir = "/config/configDropins/defaults"
 
-// Validate if the WebSpherLibertyApplication is valid
+// OpConfigDefaultTopologySpread is the key of the operator ConfigMap that enables spreading the pods of applications
+// across zones and nodes when no topology spread constraints are specified. Set it to false to disable. Defaults to true.
+const OpConfigDefaultTopologySpread = "defaultTopologySpread"
+
+// OpConfigNetworkPolicyIngressNamespace is the key of the operator ConfigMap with the namespace of the ingress controller on
+// Kubernetes, which the NetworkPolicy of applications allows incoming traffic from when spec.networkPolicy.namespaceLabels is
+// not specified
+const OpConfigNetworkPolicyIngressNamespace = "networkPolicyIngressNamespace"
+
+// IBM product identifiers reported in the license annotations
+const wasBaseProductID = "87f3487c22f34742a799164f3f3ffa78"
+const libertyCoreProductID = "cb1747ecb831410f88f60f0f7ff6b2f2"
+const wasNDProductID = "c6a988d93b0f4d1388200d40ddc84e5b"
+
+// Validate if the WebSpherLibertyApplication is valid. The validating webhook rejects invalid applications on admission,
+// so this only catches the applications that were admitted while the webhook was not serving.
 func Validate(wlapp *webspherelibertyv1.WebSphereLibertyApplication) (bool, error) {
-	// Serviceability validation
-	if wlapp.GetServiceability() != nil {
-		if wlapp.GetServiceability().GetVolumeClaimName() == "" && wlapp.GetServiceability().GetSize() == "" {
-			return false, fmt.Errorf("Invalid input for Serviceability. Specify one of the following: spec.serviceability.size, spec.serviceability.volumeClaimName")
-		}
-		if wlapp.GetServiceability().GetVolumeClaimName() == "" {
-			if _, err := resource.ParseQuantity(wlapp.GetServiceability().GetSize()); err != nil {
-				return false, fmt.Errorf("validation failed: cannot parse '%v': %v", wlapp.GetServiceability().GetSize(), err)
-			}
-		}
+	if err := wlapp.ValidateSpec(); err != nil {
+		return false, err
 	}
-
 	return true, nil
 }
 
-func requiredFieldMessage(fieldPaths ...string) string {
-	return "must set the field(s): " + strings.Join(fieldPaths, ",")
-}
-
 // ExecuteCommandInContainer Execute command inside a container in a pod through API
 func ExecuteCommandInContainer(config *rest.Config, podName, podNamespace, containerName string, command []string) (string, error) {
 
 	clientset, err := kubernetes.NewForConfig(config)
 	if err != nil {
 		log.Error(err, "Failed to create Clientset")
+		execFailuresTotal.Inc()
 		return "", fmt.Errorf("Failed to create Clientset: %v", err.Error())
 	}
 
@@ -79,6 +87,7 @@ func ExecuteCommandInContainer(config *rest.Config, podName, podNamespace, conta
 
 	exec, err := remotecommand.NewSPDYExecutor(config, "POST", req.URL())
 	if err != nil {
+		execFailuresTotal.Inc()
 		return "", fmt.Errorf("Encountered error while creating Executor: %v", err.Error())
 	}
 
@@ -90,6 +99,7 @@ func ExecuteCommandInContainer(config *rest.Config, podName, podNamespace, conta
 	})
 
 	if err != nil {
+		execFailuresTotal.Inc()
 		return stderr.String(), fmt.Errorf("Encountered error while running command: %v ; Stderr: %v ; Error: %v", command, stderr.String(), err.Error())
 	}
 
@@ -114,6 +124,18 @@ func CustomizeLibertyEnv(pts *corev1.PodTemplateSpec, la *webspherelibertyv1.Web
 	}
 
 	envList := pts.Spec.Containers[0].Env
+	if la.GetSemeruCloudCompiler() != nil {
+		// Append the JITServer options to the JVM options of the application, if any
+		jvmOptions := getSemeruJavaOptions(la)
+		if v, found := findEnvVar("OPENJ9_JAVA_OPTIONS", envList); found {
+			if v.ValueFrom == nil && !strings.Contains(v.Value, "-XX:+UseJITServer") {
+				v.Value = strings.TrimSpace(v.Value + " " + jvmOptions)
+			}
+		} else {
+			targetEnv = append(targetEnv, corev1.EnvVar{Name: "OPENJ9_JAVA_OPTIONS", Value: jvmOptions})
+		}
+	}
+
 	for _, v := range targetEnv {
 		if _, found := findEnvVar(v.Name, envList); !found {
 			pts.Spec.Containers[0].Env = append(pts.Spec.Containers[0].Env, v)
@@ -121,11 +143,143 @@ func CustomizeLibertyEnv(pts *corev1.PodTemplateSpec, la *webspherelibertyv1.Web
 	}
 }
 
+// CustomizeLibertyAnnotations adds the annotations used by the IBM License Service to report product usage
 func CustomizeLibertyAnnotations(pts *corev1.PodTemplateSpec, la *webspherelibertyv1.WebSphereLibertyApplication) {
+	license := la.GetLicense()
 	libertyAnnotations := map[string]string{
-		"libertyOperator": "WebSphere Liberty",
+		"productID":                getProductID(license.Edition),
+		"productName":              string(license.Edition),
+		"productMetric":            getProductMetric(license.Metric),
+		"productChargedContainers": "app",
 	}
 	pts.Annotations = rcoutils.MergeMaps(pts.Annotations, libertyAnnotations)
+
+	if license.ProductEntitlementSource != "" && license.ProductEntitlementSource != webspherelibertyv1.LicenseEntitlementStandalone {
+		pts.Annotations["cloudpakName"] = string(license.ProductEntitlementSource)
+	} else {
+		delete(pts.Annotations, "cloudpakName")
+	}
+}
+
+// getProductID returns the IBM product identifier of the edition
+func getProductID(edition webspherelibertyv1.LicenseEdition) string {
+	switch edition {
+	case webspherelibertyv1.LicenseEditionCore:
+		return libertyCoreProductID
+	case webspherelibertyv1.LicenseEditionND:
+		return wasNDProductID
+	default:
+		return wasBaseProductID
+	}
+}
+
+// getProductMetric returns the metric code reported to the IBM License Service
+func getProductMetric(metric webspherelibertyv1.LicenseMetric) string {
+	switch metric {
+	case webspherelibertyv1.LicenseMetricPVU:
+		return "PROCESSOR_VALUE_UNIT"
+	default:
+		return "VIRTUAL_PROCESSOR_CORE"
+	}
+}
+
+// CustomizeTopologySpreadConstraints sets the topology spread constraints of the pods. Unless disabled in the operator ConfigMap,
+// pods are spread across zones and nodes when no constraints are specified.
+func CustomizeTopologySpreadConstraints(pts *corev1.PodTemplateSpec, la *webspherelibertyv1.WebSphereLibertyApplication) {
+	if la.Spec.Affinity != nil && len(la.Spec.Affinity.GetTopologySpreadConstraints()) > 0 {
+		pts.Spec.TopologySpreadConstraints = la.Spec.Affinity.GetTopologySpreadConstraints()
+		return
+	}
+	if !GetOperatorConfig().DefaultTopologySpread {
+		pts.Spec.TopologySpreadConstraints = nil
+		return
+	}
+
+	selector := &metav1.LabelSelector{
+		MatchLabels: map[string]string{"app.kubernetes.io/instance": la.GetName()},
+	}
+	pts.Spec.TopologySpreadConstraints = []corev1.TopologySpreadConstraint{
+		{
+			MaxSkew:           1,
+			TopologyKey:       "topology.kubernetes.io/zone",
+			WhenUnsatisfiable: corev1.ScheduleAnyway,
+			LabelSelector:     selector,
+		},
+		{
+			MaxSkew:           1,
+			TopologyKey:       "kubernetes.io/hostname",
+			WhenUnsatisfiable: corev1.ScheduleAnyway,
+			LabelSelector:     selector,
+		},
+	}
+}
+
+// CustomizeSecurityContext sets the security context of the application container from the spec
+func CustomizeSecurityContext(podSpec *corev1.PodSpec, la *webspherelibertyv1.WebSphereLibertyApplication) {
+	if sc := la.GetSecurityContext(); sc != nil {
+		podSpec.Containers[0].SecurityContext = sc.DeepCopy()
+	}
+}
+
+// CustomizeInstantOn grants the application container the capabilities needed to restore a Liberty InstantOn checkpoint,
+// and sets the JVM options that apply after the restore. The fields of the security context that are set in the spec are kept.
+func CustomizeInstantOn(podSpec *corev1.PodSpec, la *webspherelibertyv1.WebSphereLibertyApplication) {
+	instantOn := la.GetInstantOn()
+	if instantOn == nil {
+		return
+	}
+
+	container := &podSpec.Containers[0]
+	if container.SecurityContext == nil {
+		container.SecurityContext = &corev1.SecurityContext{}
+	}
+	sc := container.SecurityContext
+	if sc.AllowPrivilegeEscalation == nil {
+		allowPrivilegeEscalation := true
+		sc.AllowPrivilegeEscalation = &allowPrivilegeEscalation
+	}
+	if sc.Privileged == nil {
+		privileged := false
+		sc.Privileged = &privileged
+	}
+	if sc.RunAsNonRoot == nil {
+		runAsNonRoot := true
+		sc.RunAsNonRoot = &runAsNonRoot
+	}
+	if sc.Capabilities == nil {
+		sc.Capabilities = &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}}
+	}
+	for _, capability := range []corev1.Capability{"CHECKPOINT_RESTORE", "SETPCAP"} {
+		if !containsCapability(sc.Capabilities.Add, capability) {
+			sc.Capabilities.Add = append(sc.Capabilities.Add, capability)
+		}
+	}
+
+	// OPENJ9_JAVA_OPTIONS is not read again when the JVM is restored, so the JITServer options must be part of the restore options
+	restoreOptions := instantOn.RestoreJavaOptions
+	if la.GetSemeruCloudCompiler() != nil {
+		restoreOptions = strings.TrimSpace(restoreOptions + " " + getSemeruJavaOptions(la))
+	}
+	if restoreOptions == "" {
+		return
+	}
+	if v, found := findEnvVar("OPENJ9_RESTORE_JAVA_OPTIONS", container.Env); found {
+		if v.ValueFrom == nil && !strings.Contains(v.Value, restoreOptions) {
+			v.Value = strings.TrimSpace(v.Value + " " + restoreOptions)
+		}
+	} else {
+		container.Env = append(container.Env, corev1.EnvVar{Name: "OPENJ9_RESTORE_JAVA_OPTIONS", Value: restoreOptions})
+	}
+}
+
+// containsCapability returns true if the capability is in the list
+func containsCapability(capabilities []corev1.Capability, capability corev1.Capability) bool {
+	for _, c := range capabilities {
+		if c == capability {
+			return true
+		}
+	}
+	return false
 }
 
 // findEnvVars checks if the environment variable is already present
@@ -138,6 +292,115 @@ func findEnvVar(name string, envList []corev1.EnvVar) (*corev1.EnvVar, bool) {
 	return nil, false
 }
 
+// CustomizeNetworkPolicy configures a NetworkPolicy that only allows incoming traffic to the service ports of the application pods
+// from the router or ingress controller namespace, from pods of the same application and from the additional peers
+func CustomizeNetworkPolicy(networkPolicy *networkingv1.NetworkPolicy, isOpenShift bool, la *webspherelibertyv1.WebSphereLibertyApplication) {
+	networkPolicy.Labels = la.GetLabels()
+	networkPolicy.Annotations = rcoutils.MergeMaps(networkPolicy.Annotations, la.GetAnnotations())
+
+	networkPolicy.Spec.PodSelector = metav1.LabelSelector{
+		MatchLabels: map[string]string{"app.kubernetes.io/instance": la.GetName()},
+	}
+	networkPolicy.Spec.PolicyTypes = []networkingv1.PolicyType{networkingv1.PolicyTypeIngress}
+
+	np := la.GetNetworkPolicy()
+	namespaceLabels := np.NamespaceLabels
+	if len(namespaceLabels) == 0 {
+		if isOpenShift {
+			namespaceLabels = map[string]string{"network.openshift.io/policy-group": "ingress"}
+		} else if ns := GetOperatorConfig().NetworkPolicyIngressNamespace; ns != "" {
+			namespaceLabels = map[string]string{"kubernetes.io/metadata.name": ns}
+		}
+	}
+	fromLabels := np.FromLabels
+	if len(fromLabels) == 0 {
+		fromLabels = map[string]string{"app.kubernetes.io/part-of": la.Spec.ApplicationName}
+	}
+
+	// The ingress controller namespace is only allowed when it is known
+	var peers []networkingv1.NetworkPolicyPeer
+	if len(namespaceLabels) > 0 {
+		peers = append(peers, networkingv1.NetworkPolicyPeer{NamespaceSelector: &metav1.LabelSelector{MatchLabels: namespaceLabels}})
+	}
+	peers = append(peers, networkingv1.NetworkPolicyPeer{PodSelector: &metav1.LabelSelector{MatchLabels: fromLabels}})
+	peers = append(peers, np.From...)
+
+	networkPolicy.Spec.Ingress = []networkingv1.NetworkPolicyIngressRule{
+		{From: peers, Ports: getNetworkPolicyPorts(la)},
+	}
+}
+
+// getNetworkPolicyPorts returns the container ports targeted by the application Service
+func getNetworkPolicyPorts(la *webspherelibertyv1.WebSphereLibertyApplication) []networkingv1.NetworkPolicyPort {
+	tcp := corev1.ProtocolTCP
+	port := intstr.FromInt(int(la.Spec.Service.Port))
+	if la.Spec.Service.TargetPort != nil {
+		port = intstr.FromInt(int(*la.Spec.Service.TargetPort))
+	}
+	ports := []networkingv1.NetworkPolicyPort{{Protocol: &tcp, Port: &port}}
+
+	for _, svcPort := range la.Spec.Service.Ports {
+		protocol := svcPort.Protocol
+		if protocol == "" {
+			protocol = corev1.ProtocolTCP
+		}
+		targetPort := svcPort.TargetPort
+		if targetPort.IntValue() == 0 && targetPort.Type == intstr.Int {
+			targetPort = intstr.FromInt(int(svcPort.Port))
+		}
+		ports = append(ports, networkingv1.NetworkPolicyPort{Protocol: &protocol, Port: &targetPort})
+	}
+	return ports
+}
+
+// GetMinReplicas returns the lowest number of replicas the application runs with, taking autoscaling into account
+func GetMinReplicas(la *webspherelibertyv1.WebSphereLibertyApplication) int32 {
+	if la.Spec.Autoscaling != nil {
+		if la.Spec.Autoscaling.MinReplicas != nil {
+			return *la.Spec.Autoscaling.MinReplicas
+		}
+		return 1
+	}
+	if la.Spec.Replicas != nil {
+		return *la.Spec.Replicas
+	}
+	return 1
+}
+
+// IsPodDisruptionBudgetNeeded returns true if the application runs enough replicas for a PodDisruptionBudget
+// not to block node drains
+func IsPodDisruptionBudgetNeeded(la *webspherelibertyv1.WebSphereLibertyApplication) bool {
+	return GetMinReplicas(la) > 1
+}
+
+// CustomizePodDisruptionBudget configures the PodDisruptionBudget of the application pods
+func CustomizePodDisruptionBudget(pdb *policyv1beta1.PodDisruptionBudget, la *webspherelibertyv1.WebSphereLibertyApplication) {
+	pdb.Labels = la.GetLabels()
+	pdb.Annotations = rcoutils.MergeMaps(pdb.Annotations, la.GetAnnotations())
+
+	pdb.Spec.Selector = &metav1.LabelSelector{
+		MatchLabels: map[string]string{"app.kubernetes.io/instance": la.GetName()},
+	}
+	// The pods of the image being rolled out are not counted against the budget of the application
+	if IsRolloutInProgress(la) {
+		pdb.Spec.Selector.MatchLabels[RolloutTrackLabel] = rolloutTrackStable
+	}
+
+	pdb.Spec.MinAvailable = nil
+	pdb.Spec.MaxUnavailable = nil
+	db := la.GetDisruptionBudget()
+	if db != nil && db.MaxUnavailable != nil {
+		maxUnavailable := *db.MaxUnavailable
+		pdb.Spec.MaxUnavailable = &maxUnavailable
+	} else if db != nil && db.MinAvailable != nil {
+		minAvailable := *db.MinAvailable
+		pdb.Spec.MinAvailable = &minAvailable
+	} else {
+		minAvailable := intstr.FromInt(int(GetMinReplicas(la) - 1))
+		pdb.Spec.MinAvailable = &minAvailable
+	}
+}
+
 // CreateServiceabilityPVC creates PersistentVolumeClaim for Serviceability
 func CreateServiceabilityPVC(instance *webspherelibertyv1.WebSphereLibertyApplication) *corev1.PersistentVolumeClaim {
 	persistentVolume := &corev1.PersistentVolumeClaim{
@@ -210,6 +473,126 @@ func ConfigureServiceability(pts *corev1.PodTemplateSpec, la *webspherelibertyv1
 	}
 }
 
+// CustomizeServerConfig mounts the Liberty configuration snippets from the ConfigMaps and Secrets in spec.serverConfig
+// into the configDropins directories and records their revisions so that pods are rolled when the data changes
+func CustomizeServerConfig(pts *corev1.PodTemplateSpec, la *webspherelibertyv1.WebSphereLibertyApplication, client client.Client) error {
+	if la.GetServerConfig() == nil {
+		return nil
+	}
+
+	var revisions []string
+	for i, src := range la.GetServerConfig().GetOverrides() {
+		rev, err := mountServerConfigSource(pts, la, client, src, "server-config-overrides-"+strconv.Itoa(i), serverConfigOverridesDir)
+		if err != nil {
+			return err
+		}
+		revisions = append(revisions, rev)
+	}
+	for i, src := range la.GetServerConfig().GetDefaults() {
+		rev, err := mountServerConfigSource(pts, la, client, src, "server-config-defaults-"+strconv.Itoa(i), serverConfigDefaultsDir)
+		if err != nil {
+			return err
+		}
+		revisions = append(revisions, rev)
+	}
+
+	configRev := strings.Join(revisions, ",")
+	if env, found := findEnvVar("SERVER_CONFIG_REV", pts.Spec.Containers[0].Env); found {
+		env.Value = configRev
+	} else {
+		pts.Spec.Containers[0].Env = append(pts.Spec.Containers[0].Env, corev1.EnvVar{Name: "SERVER_CONFIG_REV", Value: configRev})
+	}
+	return nil
+}
+
+// mountServerConfigSource adds a volume for the ConfigMap or Secret and mounts each of its XML keys as a file into dir.
+// Returns the resource version of the source.
+func mountServerConfigSource(pts *corev1.PodTemplateSpec, la *webspherelibertyv1.WebSphereLibertyApplication, client client.Client, src webspherelibertyv1.WebSphereLibertyApplicationServerConfigSource, volumeName string, dir string) (string, error) {
+	var keys []string
+	var rev string
+	vol := corev1.Volume{Name: volumeName}
+	if src.ConfigMapName != "" {
+		cm := &corev1.ConfigMap{}
+		err := client.Get(context.TODO(), types.NamespacedName{Name: src.ConfigMapName, Namespace: la.GetNamespace()}, cm)
+		if err != nil {
+			return "", errors.Wrapf(err, "ConfigMap %q for server configuration was not found in namespace %q", src.ConfigMapName, la.GetNamespace())
+		}
+		for k := range cm.Data {
+			keys = append(keys, k)
+		}
+		rev = cm.ResourceVersion
+		vol.VolumeSource = corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: cm.Name},
+			},
+		}
+	} else {
+		secret := &corev1.Secret{}
+		err := client.Get(context.TODO(), types.NamespacedName{Name: src.SecretName, Namespace: la.GetNamespace()}, secret)
+		if err != nil {
+			return "", errors.Wrapf(err, "Secret %q for server configuration was not found in namespace %q", src.SecretName, la.GetNamespace())
+		}
+		for k := range secret.Data {
+			keys = append(keys, k)
+		}
+		rev = secret.ResourceVersion
+		vol.VolumeSource = corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{SecretName: secret.Name},
+		}
+	}
+
+	// Liberty only processes files with the .xml extension in the configDropins directories
+	var items []corev1.KeyToPath
+	sort.Strings(keys)
+	for _, k := range keys {
+		if strings.HasSuffix(k, ".xml") {
+			items = append(items, corev1.KeyToPath{Key: k, Path: k})
+		}
+	}
+	if len(items) == 0 {
+		return rev, nil
+	}
+	if vol.ConfigMap != nil {
+		vol.ConfigMap.Items = items
+	} else {
+		vol.Secret.Items = items
+	}
+
+	foundVolume := false
+	for i, v := range pts.Spec.Volumes {
+		if v.Name == volumeName {
+			pts.Spec.Volumes[i] = vol
+			foundVolume = true
+		}
+	}
+	if !foundVolume {
+		pts.Spec.Volumes = append(pts.Spec.Volumes, vol)
+	}
+
+	// Mount each file individually so that files written into the same directory by the image or by day-2 operations are preserved
+	for _, item := range items {
+		mountPath := dir + "/" + item.Path
+		foundVolumeMount := false
+		for _, vm := range pts.Spec.Containers[0].VolumeMounts {
+			if vm.MountPath == mountPath {
+				if vm.Name != volumeName {
+					return "", fmt.Errorf("Invalid input for ServerConfig. More than one source provides the file %s", mountPath)
+				}
+				foundVolumeMount = true
+			}
+		}
+		if !foundVolumeMount {
+			pts.Spec.Containers[0].VolumeMounts = append(pts.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+				Name:      volumeName,
+				MountPath: mountPath,
+				SubPath:   item.Path,
+				ReadOnly:  true,
+			})
+		}
+	}
+	return rev, nil
+}
+
 func normalizeEnvVariableName(name string) string {
 	return strings.NewReplacer("-", "_", ".", "_").Replace(strings.ToUpper(name))
 }
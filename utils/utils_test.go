@@ -5,16 +5,33 @@ import (
 	"fmt"
 	"os"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	webspherelibertyv1 "github.com/WASdev/websphere-liberty-operator/api/v1"
+	"github.com/application-stacks/runtime-component-operator/common"
 	oputils "github.com/application-stacks/runtime-component-operator/utils"
+	prometheusv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
 	routev1 "github.com/openshift/api/route/v1"
 	v1 "github.com/openshift/api/route/v1"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/discovery"
 	fakediscovery "k8s.io/client-go/discovery/fake"
 	"k8s.io/client-go/kubernetes/scheme"
@@ -96,6 +113,54 @@ func TestCustomizeLibertyEnv(t *testing.T) {
 	}
 }
 
+func TestCustomizeLibertyAnnotations(t *testing.T) {
+	logger := zap.New()
+	logf.SetLogger(logger)
+	os.Setenv("WATCH_NAMESPACE", namespace)
+
+	// Test default license values
+	svc := &webspherelibertyv1.WebSphereLibertyApplicationService{Port: 8080, Type: &clusterType}
+	spec := webspherelibertyv1.WebSphereLibertyApplicationSpec{
+		Service: svc,
+		License: webspherelibertyv1.WebSphereLibertyApplicationLicense{Accept: true},
+	}
+	pts := &corev1.PodTemplateSpec{}
+	wl := createWebSphereLibertyApp(name, namespace, spec)
+	wl.Initialize()
+	oputils.CustomizePodSpec(pts, wl)
+	CustomizeLibertyAnnotations(pts, wl)
+
+	tests := []Test{
+		{"Product ID", wasBaseProductID, pts.Annotations["productID"]},
+		{"Product name", "IBM WebSphere Application Server", pts.Annotations["productName"]},
+		{"Product metric", "VIRTUAL_PROCESSOR_CORE", pts.Annotations["productMetric"]},
+		{"Product charged containers", "app", pts.Annotations["productChargedContainers"]},
+		{"Cloud Pak name", "", pts.Annotations["cloudpakName"]},
+	}
+	if err := verifyTests(tests); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	// Test annotations when the edition, entitlement and metric are set
+	wl.Spec.License = webspherelibertyv1.WebSphereLibertyApplicationLicense{
+		Edition:                  webspherelibertyv1.LicenseEditionND,
+		ProductEntitlementSource: webspherelibertyv1.LicenseEntitlementCP4Apps,
+		Metric:                   webspherelibertyv1.LicenseMetricPVU,
+		Accept:                   true,
+	}
+	CustomizeLibertyAnnotations(pts, wl)
+
+	tests = []Test{
+		{"Product ID", wasNDProductID, pts.Annotations["productID"]},
+		{"Product name", "IBM WebSphere Application Server Network Deployment", pts.Annotations["productName"]},
+		{"Product metric", "PROCESSOR_VALUE_UNIT", pts.Annotations["productMetric"]},
+		{"Cloud Pak name", "IBM Cloud Pak for Applications", pts.Annotations["cloudpakName"]},
+	}
+	if err := verifyTests(tests); err != nil {
+		t.Fatalf("%v", err)
+	}
+}
+
 func TestCustomizeEnvSSO(t *testing.T) {
 	logger := zap.New()
 	logf.SetLogger(logger)
@@ -230,72 +295,1321 @@ func TestCustomizeEnvSSO(t *testing.T) {
 	}
 }
 
-// Helper Functions
-func envSliceToMap(env []corev1.EnvVar, data map[string][]byte, t *testing.T) map[string]string {
-	out := map[string]string{}
-	for _, el := range env {
-		if el.ValueFrom != nil {
-			val := data[el.ValueFrom.SecretKeyRef.Key]
-			out[el.Name] = "" + string(val)
-		} else {
-			out[el.Name] = string(el.Value)
-		}
+func TestCustomizeServerConfig(t *testing.T) {
+	logger := zap.New()
+	logf.SetLogger(logger)
+	os.Setenv("WATCH_NAMESPACE", namespace)
+	svc := &webspherelibertyv1.WebSphereLibertyApplicationService{Port: 8080, Type: &clusterType}
+	spec := webspherelibertyv1.WebSphereLibertyApplicationSpec{
+		Service: svc,
+		ServerConfig: &webspherelibertyv1.WebSphereLibertyApplicationServerConfig{
+			Overrides: []webspherelibertyv1.WebSphereLibertyApplicationServerConfigSource{{ConfigMapName: "app-config"}},
+			Defaults:  []webspherelibertyv1.WebSphereLibertyApplicationServerConfigSource{{SecretName: "app-secret-config"}},
+		},
+	}
+	wl := createWebSphereLibertyApp(name, namespace, spec)
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "app-config", Namespace: namespace},
+		Data: map[string]string{
+			"logging.xml": "<server><logging traceSpecification=\"*=info\"/></server>",
+			"README":      "not server configuration",
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "app-secret-config", Namespace: namespace},
+		Data: map[string][]byte{
+			"datasource.xml": []byte("<server><dataSource id=\"db\"/></server>"),
+		},
+	}
+	cl := fakeclient.NewFakeClient(configMap, secret)
+
+	pts := &corev1.PodTemplateSpec{}
+	oputils.CustomizePodSpec(pts, wl)
+	if err := CustomizeServerConfig(pts, wl, cl); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	targetVolumeMounts := []corev1.VolumeMount{
+		{Name: "server-config-overrides-0", MountPath: serverConfigOverridesDir + "/logging.xml", SubPath: "logging.xml", ReadOnly: true},
+		{Name: "server-config-defaults-0", MountPath: serverConfigDefaultsDir + "/datasource.xml", SubPath: "datasource.xml", ReadOnly: true},
+	}
+	cl.Get(context.TODO(), types.NamespacedName{Name: configMap.Name, Namespace: namespace}, configMap)
+	cl.Get(context.TODO(), types.NamespacedName{Name: secret.Name, Namespace: namespace}, secret)
+	podEnv := envSliceToMap(pts.Spec.Containers[0].Env, nil, t)
+	tests := []Test{
+		{"Server configuration volumes", 2, len(pts.Spec.Volumes)},
+		{"ConfigMap volume items", []corev1.KeyToPath{{Key: "logging.xml", Path: "logging.xml"}}, pts.Spec.Volumes[0].ConfigMap.Items},
+		{"Secret volume name", "app-secret-config", pts.Spec.Volumes[1].Secret.SecretName},
+		{"Server configuration volume mounts", targetVolumeMounts, pts.Spec.Containers[0].VolumeMounts},
+		{"Server configuration revision", configMap.ResourceVersion + "," + secret.ResourceVersion, podEnv["SERVER_CONFIG_REV"]},
+	}
+	if err := verifyTests(tests); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	// Reapplying the configuration must not duplicate volumes or mounts
+	if err := CustomizeServerConfig(pts, wl, cl); err != nil {
+		t.Fatalf("%v", err)
+	}
+	tests = []Test{
+		{"Server configuration volumes after update", 2, len(pts.Spec.Volumes)},
+		{"Server configuration volume mounts after update", targetVolumeMounts, pts.Spec.Containers[0].VolumeMounts},
+	}
+	if err := verifyTests(tests); err != nil {
+		t.Fatalf("%v", err)
 	}
-	return out
 }
-func createWebSphereLibertyApp(n, ns string, spec webspherelibertyv1.WebSphereLibertyApplicationSpec) *webspherelibertyv1.WebSphereLibertyApplication {
-	app := &webspherelibertyv1.WebSphereLibertyApplication{
-		ObjectMeta: metav1.ObjectMeta{Name: n, Namespace: ns},
-		Spec:       spec,
+
+func TestCustomizeNetworkPolicy(t *testing.T) {
+	logger := zap.New()
+	logf.SetLogger(logger)
+	os.Setenv("WATCH_NAMESPACE", namespace)
+	targetPort := int32(9443)
+	svc := &webspherelibertyv1.WebSphereLibertyApplicationService{Port: 8080, TargetPort: &targetPort, Type: &clusterType}
+	extraPeer := networkingv1.NetworkPolicyPeer{
+		NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"name": "monitoring"}},
+	}
+	spec := webspherelibertyv1.WebSphereLibertyApplicationSpec{
+		Service:       svc,
+		NetworkPolicy: &webspherelibertyv1.WebSphereLibertyApplicationNetworkPolicy{From: []networkingv1.NetworkPolicyPeer{extraPeer}},
+	}
+	wl := createWebSphereLibertyApp(name, namespace, spec)
+	wl.Initialize()
+
+	networkPolicy := &networkingv1.NetworkPolicy{}
+	CustomizeNetworkPolicy(networkPolicy, true, wl)
+
+	port := intstr.FromInt(9443)
+	tcp := corev1.ProtocolTCP
+	tests := []Test{
+		{"Pod selector", map[string]string{"app.kubernetes.io/instance": name}, networkPolicy.Spec.PodSelector.MatchLabels},
+		{"Policy types", []networkingv1.PolicyType{networkingv1.PolicyTypeIngress}, networkPolicy.Spec.PolicyTypes},
+		{"Ingress ports", []networkingv1.NetworkPolicyPort{{Protocol: &tcp, Port: &port}}, networkPolicy.Spec.Ingress[0].Ports},
+		{"Router namespace peer", map[string]string{"network.openshift.io/policy-group": "ingress"}, networkPolicy.Spec.Ingress[0].From[0].NamespaceSelector.MatchLabels},
+		{"Application pods peer", map[string]string{"app.kubernetes.io/part-of": name}, networkPolicy.Spec.Ingress[0].From[1].PodSelector.MatchLabels},
+		{"Additional peer", extraPeer, networkPolicy.Spec.Ingress[0].From[2]},
+	}
+	if err := verifyTests(tests); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	// Configured labels replace the defaults
+	wl.Spec.NetworkPolicy = &webspherelibertyv1.WebSphereLibertyApplicationNetworkPolicy{
+		NamespaceLabels: map[string]string{"ingress": "true"},
+		FromLabels:      map[string]string{"role": "frontend"},
+	}
+	CustomizeNetworkPolicy(networkPolicy, false, wl)
+	tests = []Test{
+		{"Configured namespace peer", map[string]string{"ingress": "true"}, networkPolicy.Spec.Ingress[0].From[0].NamespaceSelector.MatchLabels},
+		{"Configured pods peer", map[string]string{"role": "frontend"}, networkPolicy.Spec.Ingress[0].From[1].PodSelector.MatchLabels},
+		{"Number of peers", 2, len(networkPolicy.Spec.Ingress[0].From)},
+	}
+	if err := verifyTests(tests); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	// On Kubernetes, the ingress controller namespace is only allowed when it is configured
+	wl.Spec.NetworkPolicy = &webspherelibertyv1.WebSphereLibertyApplicationNetworkPolicy{}
+	CustomizeNetworkPolicy(networkPolicy, false, wl)
+	tests = []Test{
+		{"No namespace peer", true, networkPolicy.Spec.Ingress[0].From[0].NamespaceSelector == nil},
+		{"Number of peers without ingress namespace", 1, len(networkPolicy.Spec.Ingress[0].From)},
+	}
+	if err := verifyTests(tests); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	cfg, errs := ParseOperatorConfig(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: OperatorConfigMapName, Namespace: namespace},
+		Data:       map[string]string{OpConfigNetworkPolicyIngressNamespace: "ingress-nginx"},
+	})
+	if len(errs) > 0 {
+		t.Fatalf("%v", errs)
+	}
+	SetOperatorConfig(cfg)
+	defer SetOperatorConfig(DefaultOperatorConfig())
+	CustomizeNetworkPolicy(networkPolicy, false, wl)
+	tests = []Test{
+		{"Ingress namespace peer", map[string]string{"kubernetes.io/metadata.name": "ingress-nginx"}, networkPolicy.Spec.Ingress[0].From[0].NamespaceSelector.MatchLabels},
+		{"Number of peers with ingress namespace", 2, len(networkPolicy.Spec.Ingress[0].From)},
+	}
+	if err := verifyTests(tests); err != nil {
+		t.Fatalf("%v", err)
 	}
-	return app
 }
 
-func createFakeDiscoveryClient() discovery.DiscoveryInterface {
-	fakeDiscoveryClient := &fakediscovery.FakeDiscovery{Fake: &coretesting.Fake{}}
-	fakeDiscoveryClient.Resources = []*metav1.APIResourceList{
-		{
-			GroupVersion: routev1.SchemeGroupVersion.String(),
-			APIResources: []metav1.APIResource{
-				{Name: "routes", Namespaced: true, Kind: "Route"},
-			},
-		},
-		{
-			GroupVersion: servingv1.SchemeGroupVersion.String(),
-			APIResources: []metav1.APIResource{
-				{Name: "services", Namespaced: true, Kind: "Service", SingularName: "service"},
-			},
-		},
+func TestCustomizePodDisruptionBudget(t *testing.T) {
+	logger := zap.New()
+	logf.SetLogger(logger)
+	os.Setenv("WATCH_NAMESPACE", namespace)
+	spec := webspherelibertyv1.WebSphereLibertyApplicationSpec{Replicas: &replicas}
+	wl := createWebSphereLibertyApp(name, namespace, spec)
+
+	pdb := &policyv1beta1.PodDisruptionBudget{}
+	CustomizePodDisruptionBudget(pdb, wl)
+	defaultMinAvailable := intstr.FromInt(int(replicas - 1))
+
+	single := int32(1)
+	singleReplica := createWebSphereLibertyApp(name, namespace, webspherelibertyv1.WebSphereLibertyApplicationSpec{Replicas: &single})
+	minReplicas := int32(4)
+	autoscaled := createWebSphereLibertyApp(name, namespace, webspherelibertyv1.WebSphereLibertyApplicationSpec{
+		Replicas:    &single,
+		Autoscaling: &webspherelibertyv1.WebSphereLibertyApplicationAutoScaling{MinReplicas: &minReplicas, MaxReplicas: 8},
+	})
+	tests := []Test{
+		{"Selector", map[string]string{"app.kubernetes.io/instance": name}, pdb.Spec.Selector.MatchLabels},
+		{"Default minAvailable", &defaultMinAvailable, pdb.Spec.MinAvailable},
+		{"Default maxUnavailable", (*intstr.IntOrString)(nil), pdb.Spec.MaxUnavailable},
+		{"PodDisruptionBudget needed", true, IsPodDisruptionBudgetNeeded(wl)},
+		{"PodDisruptionBudget for single replica", false, IsPodDisruptionBudgetNeeded(singleReplica)},
+		{"Min replicas with autoscaling", minReplicas, GetMinReplicas(autoscaled)},
+	}
+	if err := verifyTests(tests); err != nil {
+		t.Fatalf("%v", err)
 	}
 
-	return fakeDiscoveryClient
+	maxUnavailable := intstr.FromString("25%")
+	wl.Spec.DisruptionBudget = &webspherelibertyv1.WebSphereLibertyApplicationDisruptionBudget{MaxUnavailable: &maxUnavailable}
+	CustomizePodDisruptionBudget(pdb, wl)
+	tests = []Test{
+		{"Configured maxUnavailable", &maxUnavailable, pdb.Spec.MaxUnavailable},
+		{"minAvailable with maxUnavailable", (*intstr.IntOrString)(nil), pdb.Spec.MinAvailable},
+	}
+	if err := verifyTests(tests); err != nil {
+		t.Fatalf("%v", err)
+	}
 }
 
-func createReconcileRequest(n, ns string) reconcile.Request {
-	req := reconcile.Request{
-		NamespacedName: types.NamespacedName{Name: n, Namespace: ns},
+func TestCustomizeTopologySpreadConstraints(t *testing.T) {
+	logger := zap.New()
+	logf.SetLogger(logger)
+	os.Setenv("WATCH_NAMESPACE", namespace)
+	spec := webspherelibertyv1.WebSphereLibertyApplicationSpec{Replicas: &replicas}
+	wl := createWebSphereLibertyApp(name, namespace, spec)
+
+	pts := &corev1.PodTemplateSpec{}
+	CustomizeTopologySpreadConstraints(pts, wl)
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{"app.kubernetes.io/instance": name}}
+	defaultConstraints := []corev1.TopologySpreadConstraint{
+		{MaxSkew: 1, TopologyKey: "topology.kubernetes.io/zone", WhenUnsatisfiable: corev1.ScheduleAnyway, LabelSelector: selector},
+		{MaxSkew: 1, TopologyKey: "kubernetes.io/hostname", WhenUnsatisfiable: corev1.ScheduleAnyway, LabelSelector: selector},
+	}
+	tests := []Test{
+		{"Default topology spread constraints", defaultConstraints, pts.Spec.TopologySpreadConstraints},
+	}
+	if err := verifyTests(tests); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	constraints := []corev1.TopologySpreadConstraint{
+		{MaxSkew: 2, TopologyKey: "topology.kubernetes.io/region", WhenUnsatisfiable: corev1.DoNotSchedule, LabelSelector: selector},
+	}
+	wl.Spec.Affinity = &webspherelibertyv1.WebSphereLibertyApplicationAffinity{TopologySpreadConstraints: constraints}
+	CustomizeTopologySpreadConstraints(pts, wl)
+	tests = []Test{
+		{"Configured topology spread constraints", constraints, pts.Spec.TopologySpreadConstraints},
+	}
+	if err := verifyTests(tests); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	wl.Spec.Affinity = nil
+	cfg, _ := ParseOperatorConfig(&corev1.ConfigMap{Data: map[string]string{OpConfigDefaultTopologySpread: "false"}})
+	SetOperatorConfig(cfg)
+	defer SetOperatorConfig(DefaultOperatorConfig())
+	CustomizeTopologySpreadConstraints(pts, wl)
+	tests = []Test{
+		{"Disabled default topology spread constraints", []corev1.TopologySpreadConstraint(nil), pts.Spec.TopologySpreadConstraints},
+	}
+	if err := verifyTests(tests); err != nil {
+		t.Fatalf("%v", err)
 	}
-	return req
 }
 
-// verifyReconcile checks that there was no error and that the reconcile is valid
-func verifyReconcile(res reconcile.Result, err error) error {
-	if err != nil {
-		return fmt.Errorf("reconcile: (%v)", err)
+func TestCustomizeSemeruCompiler(t *testing.T) {
+	logger := zap.New()
+	logf.SetLogger(logger)
+	os.Setenv("WATCH_NAMESPACE", namespace)
+	svc := &webspherelibertyv1.WebSphereLibertyApplicationService{Port: 8080, Type: &clusterType}
+	spec := webspherelibertyv1.WebSphereLibertyApplicationSpec{
+		ApplicationImage:    appImage,
+		Service:             svc,
+		Env:                 []corev1.EnvVar{{Name: "OPENJ9_JAVA_OPTIONS", Value: "-Xshareclasses"}},
+		SemeruCloudCompiler: &webspherelibertyv1.WebSphereLibertyApplicationSemeruCloudCompiler{},
 	}
+	wl := createWebSphereLibertyApp(name, namespace, spec)
+	wl.Status.ImageReference = appImage
 
-	if res != (reconcile.Result{}) {
-		return fmt.Errorf("reconcile did not return an empty result (%v)", res)
+	pts := &corev1.PodTemplateSpec{}
+	oputils.CustomizePodSpec(pts, wl)
+	CustomizeLibertyEnv(pts, wl)
+	podEnv := envSliceToMap(pts.Spec.Containers[0].Env, nil, t)
+
+	deploy := &appsv1.Deployment{}
+	CustomizeSemeruCompilerDeployment(deploy, wl)
+	compilerSvc := &corev1.Service{}
+	CustomizeSemeruCompilerService(compilerSvc, wl)
+
+	compilerName := name + "-semeru-compiler"
+	jvmOptions := "-Xshareclasses -XX:+UseJITServer -XX:JITServerAddress=" + compilerName + "." + namespace + ".svc -XX:JITServerPort=38400"
+	tests := []Test{
+		{"JITServer JVM options", jvmOptions, podEnv["OPENJ9_JAVA_OPTIONS"]},
+		{"Compiler image", appImage, deploy.Spec.Template.Spec.Containers[0].Image},
+		{"Compiler replicas", int32(1), *deploy.Spec.Replicas},
+		{"Compiler selector", map[string]string{"app.kubernetes.io/instance": compilerName}, deploy.Spec.Selector.MatchLabels},
+		{"Compiler pod labels", compilerName, deploy.Spec.Template.Labels["app.kubernetes.io/instance"]},
+		{"Compiler Service selector", map[string]string{"app.kubernetes.io/instance": compilerName}, compilerSvc.Spec.Selector},
+		{"Compiler Service port", SemeruCompilerPort, compilerSvc.Spec.Ports[0].Port},
+		{"Compiler not ready", false, IsSemeruCompilerReady(deploy)},
+	}
+	if err := verifyTests(tests); err != nil {
+		t.Fatalf("%v", err)
 	}
 
-	return nil
+	deploy.Status.UpdatedReplicas = 1
+	deploy.Status.ReadyReplicas = 1
+	tests = []Test{
+		{"Compiler ready", true, IsSemeruCompilerReady(deploy)},
+	}
+	if err := verifyTests(tests); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	// While a new image is rolled out, the compiler keeps running the image of the application Deployment
+	wl.Status.Rollout = &webspherelibertyv1.RolloutStatus{StableImage: "old-image", Image: appImage, Phase: webspherelibertyv1.RolloutPhaseProgressing}
+	CustomizeSemeruCompilerDeployment(deploy, wl)
+	tests = []Test{
+		{"Compiler image during rollout", "old-image", deploy.Spec.Template.Spec.Containers[0].Image},
+	}
+	if err := verifyTests(tests); err != nil {
+		t.Fatalf("%v", err)
+	}
 }
 
-func verifyTests(tests []Test) error {
-	for _, tt := range tests {
-		if !reflect.DeepEqual(tt.actual, tt.expected) {
-			return fmt.Errorf("%s test expected: (%v) actual: (%v)", tt.test, tt.expected, tt.actual)
-		}
+func TestCustomizeInstantOn(t *testing.T) {
+	logger := zap.New()
+	logf.SetLogger(logger)
+	os.Setenv("WATCH_NAMESPACE", namespace)
+	svc := &webspherelibertyv1.WebSphereLibertyApplicationService{Port: 8080, Type: &clusterType}
+	spec := webspherelibertyv1.WebSphereLibertyApplicationSpec{
+		Service:             svc,
+		InstantOn:           &webspherelibertyv1.WebSphereLibertyApplicationInstantOn{RestoreJavaOptions: "-Xmx512m"},
+		SemeruCloudCompiler: &webspherelibertyv1.WebSphereLibertyApplicationSemeruCloudCompiler{},
+	}
+	wl := createWebSphereLibertyApp(name, namespace, spec)
+
+	pts := &corev1.PodTemplateSpec{}
+	oputils.CustomizePodSpec(pts, wl)
+	CustomizeInstantOn(&pts.Spec, wl)
+	podEnv := envSliceToMap(pts.Spec.Containers[0].Env, nil, t)
+
+	capabilities := &corev1.Capabilities{
+		Add:  []corev1.Capability{"CHECKPOINT_RESTORE", "SETPCAP"},
+		Drop: []corev1.Capability{"ALL"},
+	}
+	tests := []Test{
+		{"InstantOn capabilities", capabilities, pts.Spec.Containers[0].SecurityContext.Capabilities},
+		{"InstantOn privilege escalation", true, *pts.Spec.Containers[0].SecurityContext.AllowPrivilegeEscalation},
+		{"InstantOn restore options", "-Xmx512m " + getSemeruJavaOptions(wl), podEnv["OPENJ9_RESTORE_JAVA_OPTIONS"]},
+	}
+	if err := verifyTests(tests); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	// Root or privileged sidecars are rejected
+	wl.Spec.License.Accept = true
+	rootUser := int64(0)
+	wl.Spec.SidecarContainers = []corev1.Container{
+		{Name: "proxy", SecurityContext: &corev1.SecurityContext{RunAsUser: &rootUser}},
+	}
+	if _, err := Validate(wl); err == nil {
+		t.Fatalf("InstantOn with a root sidecar container was not rejected")
+	}
+	wl.Spec.SidecarContainers = nil
+	if _, err := Validate(wl); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	// A root or privileged application container is rejected
+	wl.Spec.SecurityContext = &corev1.SecurityContext{RunAsUser: &rootUser}
+	if _, err := Validate(wl); err == nil {
+		t.Fatalf("InstantOn with a root application container was not rejected")
+	}
+
+	// The capabilities are merged into the security context of the spec
+	user := int64(1001)
+	readOnly := true
+	wl.Spec.SecurityContext = &corev1.SecurityContext{
+		RunAsUser:              &user,
+		ReadOnlyRootFilesystem: &readOnly,
+		Capabilities:           &corev1.Capabilities{Add: []corev1.Capability{"NET_BIND_SERVICE"}},
+	}
+	if _, err := Validate(wl); err != nil {
+		t.Fatalf("%v", err)
+	}
+	pts = &corev1.PodTemplateSpec{}
+	oputils.CustomizePodSpec(pts, wl)
+	CustomizeSecurityContext(&pts.Spec, wl)
+	CustomizeInstantOn(&pts.Spec, wl)
+	sc := pts.Spec.Containers[0].SecurityContext
+	tests = []Test{
+		{"InstantOn keeps the user", user, *sc.RunAsUser},
+		{"InstantOn keeps the read-only root filesystem", true, *sc.ReadOnlyRootFilesystem},
+		{"InstantOn merges the capabilities", []corev1.Capability{"NET_BIND_SERVICE", "CHECKPOINT_RESTORE", "SETPCAP"}, sc.Capabilities.Add},
+		{"InstantOn runs as non-root", true, *sc.RunAsNonRoot},
+		{"Spec is not modified", 1, len(wl.Spec.SecurityContext.Capabilities.Add)},
+	}
+	if err := verifyTests(tests); err != nil {
+		t.Fatalf("%v", err)
+	}
+}
+
+func TestDefaultHealthProbes(t *testing.T) {
+	logger := zap.New()
+	logf.SetLogger(logger)
+	os.Setenv("WATCH_NAMESPACE", namespace)
+	certSecret := "my-cert"
+	svc := &webspherelibertyv1.WebSphereLibertyApplicationService{Port: 9443, Type: &clusterType, CertificateSecretRef: &certSecret}
+	defaultHealthProbes := true
+	spec := webspherelibertyv1.WebSphereLibertyApplicationSpec{
+		Service:             svc,
+		DefaultHealthProbes: &defaultHealthProbes,
+		// Partial override of the default readiness probe
+		ReadinessProbe: &corev1.Probe{PeriodSeconds: 5},
+		LivenessProbe:  &corev1.Probe{Handler: corev1.Handler{Exec: &corev1.ExecAction{Command: []string{"true"}}}},
+	}
+	wl := createWebSphereLibertyApp(name, namespace, spec)
+
+	pts := &corev1.PodTemplateSpec{}
+	oputils.CustomizePodSpec(pts, wl)
+	container := pts.Spec.Containers[0]
+
+	startupGet := &corev1.HTTPGetAction{Path: "/health/ready", Port: intstr.FromInt(9443), Scheme: corev1.URISchemeHTTPS}
+	readinessGet := &corev1.HTTPGetAction{Path: "/health/ready", Port: intstr.FromInt(9443), Scheme: corev1.URISchemeHTTPS}
+	tests := []Test{
+		{"Default startup probe", startupGet, container.StartupProbe.HTTPGet},
+		{"Default startup probe failure threshold", int32(20), container.StartupProbe.FailureThreshold},
+		{"Merged readiness probe endpoint", readinessGet, container.ReadinessProbe.HTTPGet},
+		{"Merged readiness probe period", int32(5), container.ReadinessProbe.PeriodSeconds},
+		{"Merged readiness probe initial delay", int32(10), container.ReadinessProbe.InitialDelaySeconds},
+		{"Liveness probe handler override", (*corev1.HTTPGetAction)(nil), container.LivenessProbe.HTTPGet},
+		{"Liveness probe exec", []string{"true"}, container.LivenessProbe.Exec.Command},
+		{"Liveness probe initial delay", int32(60), container.LivenessProbe.InitialDelaySeconds},
+		{"Spec is not modified", int32(0), wl.Spec.ReadinessProbe.InitialDelaySeconds},
+	}
+	if err := verifyTests(tests); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	// Without spec.defaultHealthProbes, the probes of the existing applications are not changed
+	wl.Spec.DefaultHealthProbes = nil
+	tests = []Test{
+		{"Startup probe is not defaulted", (*corev1.Probe)(nil), wl.GetStartupProbe()},
+		{"Readiness probe is not merged", wl.Spec.ReadinessProbe, wl.GetReadinessProbe()},
+	}
+	if err := verifyTests(tests); err != nil {
+		t.Fatalf("%v", err)
+	}
+}
+
+func TestCustomizeServiceMonitorEndpoints(t *testing.T) {
+	logger := zap.New()
+	logf.SetLogger(logger)
+	os.Setenv("WATCH_NAMESPACE", namespace)
+	certSecret := "my-cert"
+	svc := &webspherelibertyv1.WebSphereLibertyApplicationService{Port: 9443, Type: &clusterType, CertificateSecretRef: &certSecret}
+	spec := webspherelibertyv1.WebSphereLibertyApplicationSpec{
+		Service:    svc,
+		Monitoring: &webspherelibertyv1.WebSphereLibertyApplicationMonitoring{},
+	}
+	wl := createWebSphereLibertyApp(name, namespace, spec)
+
+	secret := &corev1.Secret{}
+	if err := CustomizeMetricsCredentialsSecret(secret, wl); err != nil {
+		t.Fatalf("%v", err)
+	}
+	password := string(secret.Data["password"])
+	if err := CustomizeMetricsCredentialsSecret(secret, wl); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	pts := &corev1.PodTemplateSpec{}
+	oputils.CustomizePodSpec(pts, wl)
+	CustomizeMetricsCredentials(pts, wl)
+	CustomizeMetricsCredentials(pts, wl)
+
+	var mounts []corev1.VolumeMount
+	for _, vm := range pts.Spec.Containers[0].VolumeMounts {
+		if vm.Name == "metrics-credentials" {
+			mounts = append(mounts, vm)
+		}
+	}
+
+	sm := &prometheusv1.ServiceMonitor{}
+	CustomizeServiceMonitor(sm, wl)
+	endpoint := sm.Spec.Endpoints[0]
+	credentials := corev1.LocalObjectReference{Name: name + "-metrics-credentials"}
+	tests := []Test{
+		{"Generated password is kept", password, string(secret.Data["password"])},
+		{"Generated metrics user", "metrics", string(secret.Data["username"])},
+		{"Metrics user is in the reader-role", true, strings.Contains(string(secret.Data["metrics-credentials.xml"]), "<reader-role>")},
+		{"Metrics user is not an administrator", false, strings.Contains(string(secret.Data["metrics-credentials.xml"]), "administrator-role")},
+		{"Metrics credentials volume mounts", 1, len(mounts)},
+		{"Metrics credentials mount path", serverConfigDefaultsDir + "/metrics-credentials.xml", mounts[0].MountPath},
+		{"Metrics path", "/metrics", endpoint.Path},
+		{"Metrics scheme", "https", endpoint.Scheme},
+		{"Metrics TLS server name", name + "." + namespace + ".svc", endpoint.TLSConfig.ServerName},
+		{"Metrics TLS CA", certSecret, endpoint.TLSConfig.CA.Secret.Name},
+		{"Metrics basic auth username", corev1.SecretKeySelector{LocalObjectReference: credentials, Key: "username"}, endpoint.BasicAuth.Username},
+	}
+	if err := verifyTests(tests); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	// User provided credentials are referenced and nothing is mounted
+	userCredentials := "my-metrics-user"
+	wl.Spec.Monitoring.CredentialsSecretRef = &userCredentials
+	wl.Spec.Service.CertificateSecretRef = nil
+	pts = &corev1.PodTemplateSpec{}
+	oputils.CustomizePodSpec(pts, wl)
+	CustomizeMetricsCredentials(pts, wl)
+	sm = &prometheusv1.ServiceMonitor{Spec: prometheusv1.ServiceMonitorSpec{Endpoints: []prometheusv1.Endpoint{{Port: "9443-tcp", Path: "/metrics/vendor"}}}}
+	CustomizeServiceMonitor(sm, wl)
+	tests = []Test{
+		{"User credentials are not mounted", 0, len(pts.Spec.Containers[0].VolumeMounts)},
+		{"User credentials basic auth", userCredentials, sm.Spec.Endpoints[0].BasicAuth.Password.Name},
+		{"User metrics path", "/metrics/vendor", sm.Spec.Endpoints[0].Path},
+		{"Metrics scheme without certificate", "https", sm.Spec.Endpoints[0].Scheme},
+		{"Metrics TLS skips verify without certificate", true, sm.Spec.Endpoints[0].TLSConfig.InsecureSkipVerify},
+	}
+	if err := verifyTests(tests); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	// Applications with their own user registry get neither generated credentials nor basic auth
+	generateCredentials := false
+	wl.Spec.Monitoring.CredentialsSecretRef = nil
+	wl.Spec.Monitoring.GenerateCredentials = &generateCredentials
+	sm = &prometheusv1.ServiceMonitor{}
+	CustomizeServiceMonitor(sm, wl)
+	tests = []Test{
+		{"Credentials are not generated", false, IsMetricsCredentialsSecretGenerated(wl)},
+		{"No basic auth without credentials", (*prometheusv1.BasicAuth)(nil), sm.Spec.Endpoints[0].BasicAuth},
+	}
+	if err := verifyTests(tests); err != nil {
+		t.Fatalf("%v", err)
+	}
+}
+
+func TestCustomizeSessionCache(t *testing.T) {
+	logger := zap.New()
+	logf.SetLogger(logger)
+	os.Setenv("WATCH_NAMESPACE", namespace)
+	svc := &webspherelibertyv1.WebSphereLibertyApplicationService{Port: 8080, Type: &clusterType}
+	spec := webspherelibertyv1.WebSphereLibertyApplicationSpec{
+		Service: svc,
+		SessionCache: &webspherelibertyv1.WebSphereLibertyApplicationSessionCache{
+			Provider:  webspherelibertyv1.SessionCacheProviderInfinispan,
+			Provision: true,
+		},
+	}
+	wl := createWebSphereLibertyApp(name, namespace, spec)
+
+	// Provisioned Infinispan clusters use the generated credentials and the cluster service
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: GetSessionCacheConfigMapName(wl), Namespace: namespace}}
+	CustomizeSessionCacheConfigMap(cm, wl)
+	secret := &corev1.Secret{}
+	if err := CustomizeSessionCacheCredentials(secret, wl); err != nil {
+		t.Fatalf("%v", err)
+	}
+	password := string(secret.Data["password"])
+	if err := CustomizeSessionCacheCredentials(secret, wl); err != nil {
+		t.Fatalf("%v", err)
+	}
+	infinispan := &unstructured.Unstructured{}
+	if err := CustomizeSessionCacheInfinispan(infinispan, wl); err != nil {
+		t.Fatalf("%v", err)
+	}
+	endpointSecret, _, _ := unstructured.NestedString(infinispan.Object, "spec", "security", "endpointSecretName")
+
+	cl := fakeclient.NewFakeClient(cm)
+	pts := &corev1.PodTemplateSpec{}
+	oputils.CustomizePodSpec(pts, wl)
+	if err := CustomizeSessionCache(pts, wl, cl); err != nil {
+		t.Fatalf("%v", err)
+	}
+	cl.Get(context.TODO(), types.NamespacedName{Name: cm.Name, Namespace: namespace}, cm)
+	podEnv := envSliceToMap(pts.Spec.Containers[0].Env, nil, t)
+	var usernameRef *corev1.SecretKeySelector
+	for _, env := range pts.Spec.Containers[0].Env {
+		if env.Name == "SESSION_CACHE_USERNAME" {
+			usernameRef = env.ValueFrom.SecretKeyRef
+		}
+	}
+	tests := []Test{
+		{"Infinispan server list", true, strings.Contains(cm.Data["session-cache.xml"], "server_list=\""+name+"-infinispan."+namespace+".svc:11222\"")},
+		{"Infinispan credentials", true, strings.Contains(cm.Data["session-cache.xml"], "${env.SESSION_CACHE_PASSWORD}")},
+		{"Infinispan deserialization is restricted", false, strings.Contains(cm.Data["session-cache.xml"], `java_serial_whitelist=".*"`)},
+		{"Generated password is kept", password, string(secret.Data["password"])},
+		{"Infinispan identities", "credentials:\n- username: liberty\n  password: " + password + "\n", string(secret.Data["identities.yaml"])},
+		{"Infinispan endpoint secret", GetSessionCacheGeneratedCredentialsName(wl), endpointSecret},
+		{"Session cache volume mount", serverConfigOverridesDir + "/session-cache.xml", pts.Spec.Containers[0].VolumeMounts[len(pts.Spec.Containers[0].VolumeMounts)-1].MountPath},
+		{"Session cache revision", cm.ResourceVersion, podEnv["SESSION_CACHE_REV"]},
+		{"Session cache username", corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: GetSessionCacheGeneratedCredentialsName(wl)}, Key: "username"}, *usernameRef},
+	}
+	if err := verifyTests(tests); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	// Hazelcast clients connect to the listed members without credentials
+	wl.Spec.SessionCache = &webspherelibertyv1.WebSphereLibertyApplicationSessionCache{
+		Provider:   webspherelibertyv1.SessionCacheProviderHazelcast,
+		ServerList: "hz-0.hz:5701, hz-1.hz:5701",
+	}
+	cm = &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: GetSessionCacheConfigMapName(wl), Namespace: namespace}}
+	CustomizeSessionCacheConfigMap(cm, wl)
+	cl = fakeclient.NewFakeClient(cm)
+	pts = &corev1.PodTemplateSpec{}
+	oputils.CustomizePodSpec(pts, wl)
+	if err := CustomizeSessionCache(pts, wl, cl); err != nil {
+		t.Fatalf("%v", err)
+	}
+	_, hasUsername := findEnvVar("SESSION_CACHE_USERNAME", pts.Spec.Containers[0].Env)
+	tests = []Test{
+		{"Hazelcast cluster name", true, strings.Contains(cm.Data["hazelcast-client.xml"], "<cluster-name>dev</cluster-name>")},
+		{"Hazelcast members", true, strings.Contains(cm.Data["hazelcast-client.xml"], "<address>hz-1.hz:5701</address>")},
+		{"Hazelcast client configuration mount", "/config/hazelcast-client.xml", pts.Spec.Containers[0].VolumeMounts[len(pts.Spec.Containers[0].VolumeMounts)-1].MountPath},
+		{"Hazelcast credentials", false, hasUsername},
+	}
+	if err := verifyTests(tests); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	// Provisioning is only supported for Infinispan
+	wl.Spec.SessionCache.Provision = true
+	wl.Spec.License.Accept = true
+	if _, err := Validate(wl); err == nil {
+		t.Fatalf("Provisioning a Hazelcast session cache was not rejected")
+	}
+}
+
+func TestApplicationStatus(t *testing.T) {
+	logger := zap.New()
+	logf.SetLogger(logger)
+	spec := webspherelibertyv1.WebSphereLibertyApplicationSpec{ApplicationImage: appImage, Replicas: &replicas}
+	wl := createWebSphereLibertyApp(name, namespace, spec)
+
+	route := &routev1.Route{Spec: routev1.RouteSpec{Host: "app.example.com", Path: "/app", TLS: &routev1.TLSConfig{}}}
+	ing := &networkingv1.Ingress{Spec: networkingv1.IngressSpec{
+		Rules: []networkingv1.IngressRule{{Host: "app.example.com"}},
+	}}
+	deploy := &appsv1.Deployment{Spec: appsv1.DeploymentSpec{Replicas: &replicas}}
+	deploy.Status.ReadyReplicas = 1
+	deploy.Status.UpdatedReplicas = 3
+	SetDeploymentStatus(wl, deploy)
+	SetReadyCondition(wl)
+
+	tests := []Test{
+		{"Route endpoint", "https://app.example.com/app", GetRouteEndpoint(route)},
+		{"Ingress endpoint", "http://app.example.com", GetIngressEndpoint(ing)},
+		{"Desired replicas", replicas, wl.Status.Replicas},
+		{"Ready replicas", int32(1), wl.Status.ReadyReplicas},
+		{"Resources not ready", corev1.ConditionFalse, getStatusCondition(wl, webspherelibertyv1.StatusConditionTypeResourcesReady).Status},
+		{"Not ready message", "1/3 pods of the Deployment are ready", getStatusCondition(wl, webspherelibertyv1.StatusConditionTypeReady).Message},
+	}
+	if err := verifyTests(tests); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	deploy.Status.ReadyReplicas = 3
+	SetDeploymentStatus(wl, deploy)
+	SetReadyCondition(wl)
+	tests = []Test{
+		{"Resources ready", corev1.ConditionTrue, getStatusCondition(wl, webspherelibertyv1.StatusConditionTypeResourcesReady).Status},
+		{"Ready", corev1.ConditionTrue, getStatusCondition(wl, webspherelibertyv1.StatusConditionTypeReady).Status},
+	}
+	if err := verifyTests(tests); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	// An existing application that does not accept the license is still valid, and reports it in its status
+	_, err := Validate(wl)
+	SetLicenseCondition(wl)
+	tests = []Test{
+		{"License not required to reconcile", nil, err},
+		{"License not accepted", corev1.ConditionFalse, getStatusCondition(wl, webspherelibertyv1.StatusConditionTypeLicenseAccepted).Status},
+		{"License not accepted reason", StatusReasonLicenseNotAccepted, getStatusCondition(wl, webspherelibertyv1.StatusConditionTypeLicenseAccepted).Reason},
+	}
+	if err := verifyTests(tests); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	wl.Spec.License.Accept = true
+	SetLicenseCondition(wl)
+	tests = []Test{
+		{"License accepted", corev1.ConditionTrue, getStatusCondition(wl, webspherelibertyv1.StatusConditionTypeLicenseAccepted).Status},
+	}
+	if err := verifyTests(tests); err != nil {
+		t.Fatalf("%v", err)
+	}
+}
+
+func TestCustomizeHPA(t *testing.T) {
+	logger := zap.New()
+	logf.SetLogger(logger)
+	minReplicas, cpu, memory := int32(2), int32(70), int32(80)
+	requestRate := autoscalingv2beta2.MetricSpec{
+		Type: autoscalingv2beta2.PodsMetricSourceType,
+		Pods: &autoscalingv2beta2.PodsMetricSource{
+			Metric: autoscalingv2beta2.MetricIdentifier{Name: "servlet_request_rate"},
+			Target: autoscalingv2beta2.MetricTarget{Type: autoscalingv2beta2.AverageValueMetricType, AverageValue: resource.NewQuantity(100, resource.DecimalSI)},
+		},
+	}
+	stabilization := int32(600)
+	behavior := &autoscalingv2beta2.HorizontalPodAutoscalerBehavior{
+		ScaleDown: &autoscalingv2beta2.HPAScalingRules{StabilizationWindowSeconds: &stabilization},
+	}
+	spec := webspherelibertyv1.WebSphereLibertyApplicationSpec{
+		ApplicationImage: appImage,
+		Autoscaling: &webspherelibertyv1.WebSphereLibertyApplicationAutoScaling{
+			MaxReplicas:                       5,
+			MinReplicas:                       &minReplicas,
+			TargetCPUUtilizationPercentage:    &cpu,
+			TargetMemoryUtilizationPercentage: &memory,
+			Metrics:                           []autoscalingv2beta2.MetricSpec{requestRate},
+			Behavior:                          behavior,
+		},
+	}
+	wl := createWebSphereLibertyApp(name, namespace, spec)
+
+	hpa := &autoscalingv2beta2.HorizontalPodAutoscaler{}
+	CustomizeHPA(hpa, wl)
+
+	tests := []Test{
+		{"Max replicas", int32(5), hpa.Spec.MaxReplicas},
+		{"Min replicas", minReplicas, *hpa.Spec.MinReplicas},
+		{"Scale target", "Deployment", hpa.Spec.ScaleTargetRef.Kind},
+		{"Metric count", 3, len(hpa.Spec.Metrics)},
+		{"CPU metric", corev1.ResourceCPU, hpa.Spec.Metrics[0].Resource.Name},
+		{"CPU utilization", cpu, *hpa.Spec.Metrics[0].Resource.Target.AverageUtilization},
+		{"Memory metric", corev1.ResourceMemory, hpa.Spec.Metrics[1].Resource.Name},
+		{"Memory utilization", memory, *hpa.Spec.Metrics[1].Resource.Target.AverageUtilization},
+		{"Request rate metric", requestRate, hpa.Spec.Metrics[2]},
+		{"Behavior", behavior, hpa.Spec.Behavior},
+	}
+	if err := verifyTests(tests); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	// Without targets, the autoscaler defaults to CPU utilization
+	wl.Spec.Autoscaling = &webspherelibertyv1.WebSphereLibertyApplicationAutoScaling{MaxReplicas: 3}
+	CustomizeHPA(hpa, wl)
+	tests = []Test{
+		{"No metrics", 0, len(hpa.Spec.Metrics)},
+	}
+	if err := verifyTests(tests); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	// The autoscaling/v2beta2 API is only needed beyond CPU utilization
+	v2beta2Needed := IsAutoscalingV2beta2Needed(wl)
+	wl.Spec.Autoscaling.TargetCPUUtilizationPercentage = &cpu
+	wl.Spec.StatefulSet = &webspherelibertyv1.WebSphereLibertyApplicationStatefulSet{}
+	cpuHPA := &autoscalingv1.HorizontalPodAutoscaler{ObjectMeta: metav1.ObjectMeta{
+		Annotations: map[string]string{hpaMetricsAnnotation: "[]"},
+	}}
+	CustomizeCPUHPA(cpuHPA, wl)
+	_, hasMetrics := cpuHPA.Annotations[hpaMetricsAnnotation]
+	tests = []Test{
+		{"CPU only", false, v2beta2Needed},
+		{"CPU only with target", false, IsAutoscalingV2beta2Needed(wl)},
+		{"Memory", true, IsAutoscalingV2beta2Needed(createWebSphereLibertyApp(name, namespace, spec))},
+		{"v1 max replicas", int32(3), cpuHPA.Spec.MaxReplicas},
+		{"v1 scale target", "StatefulSet", cpuHPA.Spec.ScaleTargetRef.Kind},
+		{"v1 CPU utilization", cpu, *cpuHPA.Spec.TargetCPUUtilizationPercentage},
+		{"v1 metrics removed", false, hasMetrics},
+	}
+	if err := verifyTests(tests); err != nil {
+		t.Fatalf("%v", err)
+	}
+}
+
+func TestRolloutSelectors(t *testing.T) {
+	logger := zap.New()
+	logf.SetLogger(logger)
+	os.Setenv("WATCH_NAMESPACE", namespace)
+	svc := &webspherelibertyv1.WebSphereLibertyApplicationService{Port: 9080, Type: &clusterType}
+	spec := webspherelibertyv1.WebSphereLibertyApplicationSpec{
+		ApplicationImage: appImage,
+		Service:          svc,
+		Rollout:          &webspherelibertyv1.WebSphereLibertyApplicationRollout{Canary: &webspherelibertyv1.WebSphereLibertyApplicationCanary{}},
+	}
+	wl := createWebSphereLibertyApp(name, namespace, spec)
+	wl.Status.Rollout = &webspherelibertyv1.RolloutStatus{StableImage: "old-image"}
+	StartRollout(wl, appImage, metav1.Now())
+
+	stable := &appsv1.Deployment{}
+	oputils.CustomizeDeployment(stable, wl)
+	oputils.CustomizePodSpec(&stable.Spec.Template, wl)
+	CustomizeStableTrack(stable, nil, wl)
+	rollout := &appsv1.Deployment{}
+	oputils.CustomizeDeployment(rollout, wl)
+	oputils.CustomizePodSpec(&rollout.Spec.Template, wl)
+	CustomizeRolloutDeployment(rollout, wl, 1)
+	stableSvc := &corev1.Service{}
+	oputils.CustomizeService(stableSvc, wl)
+	CustomizeStableService(stableSvc, wl)
+	rolloutSvc := &corev1.Service{}
+	oputils.CustomizeService(rolloutSvc, wl)
+	CustomizeRolloutService(rolloutSvc, wl)
+
+	// The pods of both Deployments are selected by the instance label, but each Service only selects the pods of its track
+	stablePods := labels.Set(stable.Spec.Template.Labels)
+	rolloutPods := labels.Set(rollout.Spec.Template.Labels)
+	tests := []Test{
+		{"Rollout pods keep the instance label", name, rolloutPods["app.kubernetes.io/instance"]},
+		{"Application Service selects stable pods", true, labels.SelectorFromSet(stableSvc.Spec.Selector).Matches(stablePods)},
+		{"Application Service does not select rollout pods", false, labels.SelectorFromSet(stableSvc.Spec.Selector).Matches(rolloutPods)},
+		{"Rollout Service selects rollout pods", true, labels.SelectorFromSet(rolloutSvc.Spec.Selector).Matches(rolloutPods)},
+		{"Rollout Service does not select stable pods", false, labels.SelectorFromSet(rolloutSvc.Spec.Selector).Matches(stablePods)},
+		{"Application Deployment selects stable pods", true, labels.SelectorFromSet(stable.Spec.Selector.MatchLabels).Matches(stablePods)},
+		{"Application Deployment does not select rollout pods", false, labels.SelectorFromSet(stable.Spec.Selector.MatchLabels).Matches(rolloutPods)},
+		{"Rollout Deployment does not select stable pods", false, labels.SelectorFromSet(rollout.Spec.Selector.MatchLabels).Matches(stablePods)},
+	}
+	if err := verifyTests(tests); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	// The selector of a Deployment created without a rollout strategy is kept, and it keeps selecting the rollout pods. The
+	// PodDisruptionBudget only selects the stable pods during the rollout.
+	existing := &appsv1.Deployment{}
+	existingSelector := &metav1.LabelSelector{MatchLabels: map[string]string{"app.kubernetes.io/instance": name}}
+	oputils.CustomizeDeployment(existing, wl)
+	oputils.CustomizePodSpec(&existing.Spec.Template, wl)
+	CustomizeStableTrack(existing, existingSelector, wl)
+	pdb := &policyv1beta1.PodDisruptionBudget{}
+	CustomizePodDisruptionBudget(pdb, wl)
+	tests = []Test{
+		{"Existing Deployment selector is kept", existingSelector, existing.Spec.Selector},
+		{"Existing Deployment pods are stable", rolloutTrackStable, existing.Spec.Template.Labels[RolloutTrackLabel]},
+		{"Existing Deployment selects rollout pods", true, labels.SelectorFromSet(existing.Spec.Selector.MatchLabels).Matches(rolloutPods)},
+		{"PodDisruptionBudget selects stable pods", true, labels.SelectorFromSet(pdb.Spec.Selector.MatchLabels).Matches(stablePods)},
+		{"PodDisruptionBudget does not select rollout pods", false, labels.SelectorFromSet(pdb.Spec.Selector.MatchLabels).Matches(rolloutPods)},
+	}
+	if err := verifyTests(tests); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	// Once the rollout strategy is removed, the pods keep matching the stable selector
+	wl.Spec.Rollout = nil
+	CustomizeStableTrack(stable, stable.Spec.Selector, wl)
+	if !labels.SelectorFromSet(stable.Spec.Selector.MatchLabels).Matches(labels.Set(stable.Spec.Template.Labels)) {
+		t.Fatalf("Application Deployment does not select its pods without a rollout strategy")
+	}
+	wl.Spec.Rollout = spec.Rollout
+
+	// The traffic of an Ingress can only be shifted by the NGINX ingress controller
+	className := "traefik"
+	wl.Spec.Ingress = &webspherelibertyv1.WebSphereLibertyApplicationIngress{IngressClassName: &className}
+	if err := ValidateRolloutIngress(wl); err == nil {
+		t.Fatalf("Rollout with the %s ingress class was not rejected", className)
+	}
+	className = "nginx"
+	if err := ValidateRolloutIngress(wl); err != nil {
+		t.Fatalf("%v", err)
+	}
+}
+
+func TestUpdateRolloutStatus(t *testing.T) {
+	logger := zap.New()
+	logf.SetLogger(logger)
+	spec := webspherelibertyv1.WebSphereLibertyApplicationSpec{
+		ApplicationImage: appImage,
+		Rollout: &webspherelibertyv1.WebSphereLibertyApplicationRollout{
+			Canary: &webspherelibertyv1.WebSphereLibertyApplicationCanary{
+				Steps: []webspherelibertyv1.WebSphereLibertyApplicationCanaryStep{
+					{Weight: 10, Pause: &metav1.Duration{Duration: time.Minute}},
+					{Weight: 50},
+				},
+			},
+		},
+	}
+	wl := createWebSphereLibertyApp(name, namespace, spec)
+	start := metav1.Now()
+	wl.Status.Rollout = &webspherelibertyv1.RolloutStatus{StableImage: "old-image"}
+	StartRollout(wl, appImage, start)
+
+	// No traffic is sent to the canary until its pods are ready
+	deploy := &appsv1.Deployment{Spec: appsv1.DeploymentSpec{Replicas: &replicas}}
+	UpdateRolloutStatus(wl, deploy, start)
+	route := &routev1.Route{}
+	CustomizeRolloutRoute(route, wl)
+	tests := []Test{
+		{"Canary replicas", int32(1), GetRolloutReplicas(wl, replicas)},
+		{"Weight before ready", int32(0), wl.Status.Rollout.Weight},
+		{"Route without canary", 0, len(route.Spec.AlternateBackends)},
+	}
+	if err := verifyTests(tests); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	deploy.Status.ReadyReplicas = 1
+	UpdateRolloutStatus(wl, deploy, start)
+	CustomizeRolloutRoute(route, wl)
+	tests = []Test{
+		{"First step weight", int32(10), wl.Status.Rollout.Weight},
+		{"Paused on first step", int32(0), wl.Status.Rollout.Step},
+		{"Stable Route weight", int32(90), *route.Spec.To.Weight},
+		{"Canary Route backend", name + "-rollout", route.Spec.AlternateBackends[0].Name},
+	}
+	if err := verifyTests(tests); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	// After the pause, the last step is applied and the canary is promoted
+	later := metav1.NewTime(start.Add(2 * time.Minute))
+	UpdateRolloutStatus(wl, deploy, later)
+	UpdateRolloutStatus(wl, deploy, later)
+	tests = []Test{
+		{"Last step weight", int32(50), wl.Status.Rollout.Weight},
+		{"Promoting", webspherelibertyv1.RolloutPhasePromoting, wl.Status.Rollout.Phase},
+		{"Promoted replicas", replicas, GetRolloutReplicas(wl, replicas)},
+	}
+	if err := verifyTests(tests); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	deploy.Status.ReadyReplicas = replicas
+	deploy.Status.UpdatedReplicas = replicas
+	UpdateRolloutStatus(wl, deploy, later)
+	tests = []Test{
+		{"All traffic switched", int32(100), wl.Status.Rollout.Weight},
+	}
+	if err := verifyTests(tests); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	// A new image whose pods are not ready before the deadline is aborted
+	StartRollout(wl, "bad-image", start)
+	deploy.Status.ReadyReplicas = 0
+	UpdateRolloutStatus(wl, deploy, metav1.NewTime(start.Add(11*time.Minute)))
+	tests = []Test{
+		{"Aborted", webspherelibertyv1.RolloutPhaseAborted, wl.Status.Rollout.Phase},
+		{"Aborted weight", int32(0), GetRolloutWeight(wl)},
+	}
+	if err := verifyTests(tests); err != nil {
+		t.Fatalf("%v", err)
+	}
+}
+
+func TestCustomizeIngress(t *testing.T) {
+	logger := zap.New()
+	logf.SetLogger(logger)
+	className := "nginx"
+	exact := networkingv1.PathTypeExact
+	spec := webspherelibertyv1.WebSphereLibertyApplicationSpec{
+		ApplicationImage: appImage,
+		Service: &webspherelibertyv1.WebSphereLibertyApplicationService{
+			Port:  9443,
+			Ports: []corev1.ServicePort{{Name: "admin", Port: 9080}},
+		},
+		Ingress: &webspherelibertyv1.WebSphereLibertyApplicationIngress{
+			IngressClassName: &className,
+			Rules: []webspherelibertyv1.WebSphereLibertyApplicationIngressRule{
+				{Host: "app.example.com", TLSSecretName: "app-tls"},
+				{Host: "admin.example.com", Paths: []webspherelibertyv1.WebSphereLibertyApplicationIngressPath{
+					{Path: "/adminCenter", PathType: &exact, Port: 9080},
+				}},
+			},
+		},
+	}
+	wl := createWebSphereLibertyApp(name, namespace, spec)
+
+	ing := &networkingv1.Ingress{}
+	CustomizeIngress(ing, wl)
+	appPath := ing.Spec.Rules[0].HTTP.Paths[0]
+	adminPath := ing.Spec.Rules[1].HTTP.Paths[0]
+	tests := []Test{
+		{"Ingress class", &className, ing.Spec.IngressClassName},
+		{"Rules", 2, len(ing.Spec.Rules)},
+		{"Default path", "/", appPath.Path},
+		{"Default path type", networkingv1.PathTypePrefix, *appPath.PathType},
+		{"Default port", networkingv1.ServiceBackendPort{Name: "9443-tcp"}, appPath.Backend.Service.Port},
+		{"Admin host", "admin.example.com", ing.Spec.Rules[1].Host},
+		{"Admin path type", networkingv1.PathTypeExact, *adminPath.PathType},
+		{"Admin port", networkingv1.ServiceBackendPort{Number: 9080}, adminPath.Backend.Service.Port},
+		{"TLS", []networkingv1.IngressTLS{{Hosts: []string{"app.example.com"}, SecretName: "app-tls"}}, ing.Spec.TLS},
+		{"Endpoint", "https://app.example.com", GetIngressEndpoint(ing)},
+	}
+	if err := verifyTests(tests); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	// The hosts without a TLS secret use the certificate issued for them
+	routeCert := "my-app-route-tls"
+	wl.Spec.Route = &webspherelibertyv1.WebSphereLibertyApplicationRoute{CertificateSecretRef: &routeCert}
+	CustomizeIngress(ing, wl)
+	tests = []Test{
+		{"Certificate hosts", []string{"admin.example.com"}, GetRouteCertificateHosts(wl)},
+		{"Issued TLS", []networkingv1.IngressTLS{
+			{Hosts: []string{"app.example.com"}, SecretName: "app-tls"},
+			{Hosts: []string{"admin.example.com"}, SecretName: routeCert},
+		}, ing.Spec.TLS},
+	}
+	if err := verifyTests(tests); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	// Paths must be sent to a port of the application Service
+	wl.Spec.Ingress.Rules[1].Paths[0].Port = 9060
+	wl.Spec.License.Accept = true
+	if _, err := Validate(wl); err == nil {
+		t.Fatalf("An Ingress path to a port that is not exposed by the Service was not rejected")
+	}
+}
+
+func TestCustomizeHTTPRoute(t *testing.T) {
+	logger := zap.New()
+	logf.SetLogger(logger)
+	expose := true
+	spec := webspherelibertyv1.WebSphereLibertyApplicationSpec{
+		ApplicationImage: appImage,
+		Expose:           &expose,
+		Service:          &webspherelibertyv1.WebSphereLibertyApplicationService{Port: 9443},
+		Route:            &webspherelibertyv1.WebSphereLibertyApplicationRoute{Host: "app.example.com", Path: "/app"},
+		GatewayRoute: &webspherelibertyv1.WebSphereLibertyApplicationGatewayRoute{
+			Gateway: webspherelibertyv1.WebSphereLibertyApplicationGatewayReference{Name: "gateway", Namespace: "gateways", SectionName: "https"},
+		},
+	}
+	wl := createWebSphereLibertyApp(name, namespace, spec)
+	gateway := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"listeners": []interface{}{
+				map[string]interface{}{"name": "http", "protocol": "HTTP"},
+				map[string]interface{}{"name": "https", "protocol": "HTTPS", "hostname": "*.example.com"},
+			},
+		},
+	}}
+
+	// The host and path of spec.route are used by default
+	route := &unstructured.Unstructured{}
+	if err := CustomizeHTTPRoute(route, wl); err != nil {
+		t.Fatalf("%v", err)
+	}
+	parentRefs, _, _ := unstructured.NestedSlice(route.Object, "spec", "parentRefs")
+	hostnames, _, _ := unstructured.NestedStringSlice(route.Object, "spec", "hostnames")
+	rules, _, _ := unstructured.NestedSlice(route.Object, "spec", "rules")
+	rule := rules[0].(map[string]interface{})
+	tests := []Test{
+		{"Gateway", map[string]interface{}{"group": "gateway.networking.k8s.io", "kind": "Gateway", "name": "gateway", "namespace": "gateways", "sectionName": "https"}, parentRefs[0]},
+		{"Default hostnames", []string{"app.example.com"}, hostnames},
+		{"Default matches", []interface{}{map[string]interface{}{"path": map[string]interface{}{"type": "PathPrefix", "value": "/app"}}}, rule["matches"]},
+		{"Backend", []interface{}{map[string]interface{}{"group": "", "kind": "Service", "name": name, "port": int64(9443), "weight": int64(100)}}, rule["backendRefs"]},
+		{"HTTPS endpoint", "https://app.example.com/app", GetHTTPRouteEndpoint(wl, gateway)},
+	}
+	if err := verifyTests(tests); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	// Header matches, and the traffic split with the image being rolled out
+	wl.Spec.GatewayRoute.Hostnames = []string{"liberty.example.com"}
+	wl.Spec.GatewayRoute.Matches = []webspherelibertyv1.WebSphereLibertyApplicationGatewayRouteMatch{
+		{Path: "/api", PathType: "Exact", Headers: []webspherelibertyv1.WebSphereLibertyApplicationGatewayHeaderMatch{{Name: "x-version", Value: "v2"}}},
+	}
+	wl.Spec.GatewayRoute.Gateway.SectionName = "http"
+	wl.Status.Rollout = &webspherelibertyv1.RolloutStatus{Phase: webspherelibertyv1.RolloutPhaseProgressing, Weight: 20}
+	if err := CustomizeHTTPRoute(route, wl); err != nil {
+		t.Fatalf("%v", err)
+	}
+	rules, _, _ = unstructured.NestedSlice(route.Object, "spec", "rules")
+	rule = rules[0].(map[string]interface{})
+	backendRefs := rule["backendRefs"].([]interface{})
+	tests = []Test{
+		{"Header match", []interface{}{map[string]interface{}{"path": map[string]interface{}{"type": "Exact", "value": "/api"},
+			"headers": []interface{}{map[string]interface{}{"name": "x-version", "type": "Exact", "value": "v2"}}}}, rule["matches"]},
+		{"Stable weight", int64(80), backendRefs[0].(map[string]interface{})["weight"]},
+		{"Rollout backend", name + "-rollout", backendRefs[1].(map[string]interface{})["name"]},
+		{"HTTP endpoint", "http://liberty.example.com/api", GetHTTPRouteEndpoint(wl, gateway)},
+	}
+	if err := verifyTests(tests); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	// The Gateway API is not supported with Knative
+	createKnativeService := true
+	wl.Spec.CreateKnativeService = &createKnativeService
+	wl.Spec.License.Accept = true
+	if _, err := Validate(wl); err == nil {
+		t.Fatalf("A gateway route with a Knative service was not rejected")
+	}
+}
+
+func TestCustomizeServiceMesh(t *testing.T) {
+	logger := zap.New()
+	logf.SetLogger(logger)
+	maxConnections := int32(100)
+	maxRequestsPerConnection := int32(1)
+	spec := webspherelibertyv1.WebSphereLibertyApplicationSpec{
+		ApplicationImage: appImage,
+		Service:          &webspherelibertyv1.WebSphereLibertyApplicationService{Port: 9080},
+		ServiceMesh: &webspherelibertyv1.WebSphereLibertyApplicationServiceMesh{
+			Gateways: []string{"istio-system/ingress"},
+			ConnectionPool: &webspherelibertyv1.WebSphereLibertyApplicationConnectionPool{
+				MaxConnections:           &maxConnections,
+				ConnectTimeout:           &metav1.Duration{Duration: 90 * time.Second},
+				MaxRequestsPerConnection: &maxRequestsPerConnection,
+			},
+		},
+	}
+	wl := createWebSphereLibertyApp(name, namespace, spec)
+
+	pts := &corev1.PodTemplateSpec{}
+	CustomizeServiceMesh(pts, wl)
+	vs, dr, pa := &unstructured.Unstructured{}, &unstructured.Unstructured{}, &unstructured.Unstructured{}
+	if err := CustomizeVirtualService(vs, wl); err != nil {
+		t.Fatalf("%v", err)
+	}
+	if err := CustomizeDestinationRule(dr, wl); err != nil {
+		t.Fatalf("%v", err)
+	}
+	if err := CustomizePeerAuthentication(pa, wl); err != nil {
+		t.Fatalf("%v", err)
+	}
+	gateways, _, _ := unstructured.NestedStringSlice(vs.Object, "spec", "gateways")
+	routes, _, _ := unstructured.NestedSlice(vs.Object, "spec", "http")
+	tlsMode, _, _ := unstructured.NestedString(dr.Object, "spec", "trafficPolicy", "tls", "mode")
+	connectTimeout, _, _ := unstructured.NestedString(dr.Object, "spec", "trafficPolicy", "connectionPool", "tcp", "connectTimeout")
+	maxRequests, _, _ := unstructured.NestedInt64(dr.Object, "spec", "trafficPolicy", "connectionPool", "http", "maxRequestsPerConnection")
+	mtlsMode, _, _ := unstructured.NestedString(pa.Object, "spec", "mtls", "mode")
+	paSelector, _, _ := unstructured.NestedStringMap(pa.Object, "spec", "selector", "matchLabels")
+	tests := []Test{
+		{"Sidecar injection", "true", pts.Annotations["sidecar.istio.io/inject"]},
+		{"Probe rewrite", "true", pts.Annotations["sidecar.istio.io/rewriteAppHTTPProbers"]},
+		{"Gateways", []string{"mesh", "istio-system/ingress"}, gateways},
+		{"HTTP route", []interface{}{map[string]interface{}{"destination": map[string]interface{}{"host": name, "port": map[string]interface{}{"number": int64(9080)}}, "weight": int64(100)}},
+			routes[0].(map[string]interface{})["route"]},
+		{"DestinationRule TLS mode", "ISTIO_MUTUAL", tlsMode},
+		{"Connect timeout", "90s", connectTimeout},
+		{"Max requests per connection", int64(1), maxRequests},
+		{"PeerAuthentication mode", "PERMISSIVE", mtlsMode},
+		{"PeerAuthentication selector", map[string]string{"app.kubernetes.io/instance": name}, paSelector},
+	}
+	if err := verifyTests(tests); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	// HTTPS traffic is routed on its SNI host, and mutual TLS can be turned off
+	certSecret := "app-tls"
+	wl.Spec.Service.CertificateSecretRef = &certSecret
+	wl.Spec.ServiceMesh.MTLSMode = "DISABLE"
+	if err := CustomizeVirtualService(vs, wl); err != nil {
+		t.Fatalf("%v", err)
+	}
+	if err := CustomizeDestinationRule(dr, wl); err != nil {
+		t.Fatalf("%v", err)
+	}
+	_, hasHTTP, _ := unstructured.NestedSlice(vs.Object, "spec", "http")
+	tlsRoutes, _, _ := unstructured.NestedSlice(vs.Object, "spec", "tls")
+	tlsMode, _, _ = unstructured.NestedString(dr.Object, "spec", "trafficPolicy", "tls", "mode")
+	tests = []Test{
+		{"No HTTP route", false, hasHTTP},
+		{"TLS route", 1, len(tlsRoutes)},
+		{"DestinationRule TLS disabled", "DISABLE", tlsMode},
+	}
+	if err := verifyTests(tests); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	// The sidecar is no longer injected once the service mesh is disabled
+	wl.Spec.ServiceMesh = nil
+	CustomizeServiceMesh(pts, wl)
+	if _, ok := pts.Annotations["sidecar.istio.io/inject"]; ok {
+		t.Fatalf("The sidecar injection annotation was not removed")
+	}
+}
+
+// Helper Functions
+func envSliceToMap(env []corev1.EnvVar, data map[string][]byte, t *testing.T) map[string]string {
+	out := map[string]string{}
+	for _, el := range env {
+		if el.ValueFrom != nil {
+			val := data[el.ValueFrom.SecretKeyRef.Key]
+			out[el.Name] = "" + string(val)
+		} else {
+			out[el.Name] = string(el.Value)
+		}
+	}
+	return out
+}
+func createWebSphereLibertyApp(n, ns string, spec webspherelibertyv1.WebSphereLibertyApplicationSpec) *webspherelibertyv1.WebSphereLibertyApplication {
+	app := &webspherelibertyv1.WebSphereLibertyApplication{
+		ObjectMeta: metav1.ObjectMeta{Name: n, Namespace: ns},
+		Spec:       spec,
+	}
+	return app
+}
+
+func createFakeDiscoveryClient() discovery.DiscoveryInterface {
+	fakeDiscoveryClient := &fakediscovery.FakeDiscovery{Fake: &coretesting.Fake{}}
+	fakeDiscoveryClient.Resources = []*metav1.APIResourceList{
+		{
+			GroupVersion: routev1.SchemeGroupVersion.String(),
+			APIResources: []metav1.APIResource{
+				{Name: "routes", Namespaced: true, Kind: "Route"},
+			},
+		},
+		{
+			GroupVersion: servingv1.SchemeGroupVersion.String(),
+			APIResources: []metav1.APIResource{
+				{Name: "services", Namespaced: true, Kind: "Service", SingularName: "service"},
+			},
+		},
+	}
+
+	return fakeDiscoveryClient
+}
+
+func createReconcileRequest(n, ns string) reconcile.Request {
+	req := reconcile.Request{
+		NamespacedName: types.NamespacedName{Name: n, Namespace: ns},
+	}
+	return req
+}
+
+// verifyReconcile checks that there was no error and that the reconcile is valid
+func verifyReconcile(res reconcile.Result, err error) error {
+	if err != nil {
+		return fmt.Errorf("reconcile: (%v)", err)
+	}
+
+	if res != (reconcile.Result{}) {
+		return fmt.Errorf("reconcile did not return an empty result (%v)", res)
+	}
+
+	return nil
+}
+
+func verifyTests(tests []Test) error {
+	for _, tt := range tests {
+		if !reflect.DeepEqual(tt.actual, tt.expected) {
+			return fmt.Errorf("%s test expected: (%v) actual: (%v)", tt.test, tt.expected, tt.actual)
+		}
+	}
+	return nil
+}
+
+func TestRecordReconcile(t *testing.T) {
+	logger := zap.New()
+	logf.SetLogger(logger)
+	kind := "TestRecordReconcile"
+	conflict := kerrors.NewConflict(schema.GroupResource{Group: "apps", Resource: "deployments"}, name, fmt.Errorf("object was modified"))
+
+	RecordReconcile(kind, nil)
+	RecordReconcile(kind, conflict)
+	RecordReconcile(kind, errors.Wrap(conflict, "failed to reconcile Deployment"))
+	RecordReconcile(kind, fmt.Errorf("unexpected error"))
+	RecordReconcile(kind, NewReconcileError(ReconcileReasonInvalidSpec, fmt.Errorf("invalid input")))
+	RecordOperation(kind, time.Now(), nil)
+	RecordOperation(kind, time.Now(), NewReconcileError(ReconcileReasonExecFailed, fmt.Errorf("command terminated with exit code 1")))
+
+	tests := []Test{
+		{"Successful reconciles", float64(2), testutil.ToFloat64(reconcileTotal.WithLabelValues(kind, metricsResultSuccess))},
+		{"Failed reconciles", float64(5), testutil.ToFloat64(reconcileTotal.WithLabelValues(kind, metricsResultError))},
+		{"Conflict errors, including wrapped ones", float64(2), testutil.ToFloat64(reconcileErrorsTotal.WithLabelValues(kind, "Conflict"))},
+		{"Other errors", float64(1), testutil.ToFloat64(reconcileErrorsTotal.WithLabelValues(kind, StatusReasonReconcileFailed))},
+		{"Invalid spec errors", float64(1), testutil.ToFloat64(reconcileErrorsTotal.WithLabelValues(kind, ReconcileReasonInvalidSpec))},
+		{"Failed operation errors", float64(1), testutil.ToFloat64(reconcileErrorsTotal.WithLabelValues(kind, ReconcileReasonExecFailed))},
+		{"Successful operations", float64(1), testutil.ToFloat64(operationsTotal.WithLabelValues(kind, metricsResultSuccess))},
+		{"Failed operations", float64(1), testutil.ToFloat64(operationsTotal.WithLabelValues(kind, metricsResultError))},
+		{"Error message of a reconcile error", "invalid input", NewReconcileError(ReconcileReasonInvalidSpec, fmt.Errorf("invalid input")).Error()},
+	}
+	if err := verifyTests(tests); err != nil {
+		t.Fatalf("%v", err)
+	}
+}
+
+func TestParseOperatorConfig(t *testing.T) {
+	logger := zap.New()
+	logf.SetLogger(logger)
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: OperatorConfigMapName, Namespace: namespace},
+		Data: map[string]string{
+			common.OpConfigDefaultHostname: "apps.example.com",
+			OpConfigDefaultPullPolicy:      "Always",
+			OpConfigDefaultResources:       "requests:\n  cpu: 100m\n  memory: 512Mi\n",
+			OpConfigDefaultReadinessProbe:  "periodSeconds: 5\n",
+			OpConfigCertManagerIssuer:      "my-issuer",
+			OpConfigCertManagerIssuerKind:  "Namespace",
+			OpConfigDefaultTopologySpread:  "no",
+			"defaultPulPolicy":             "Never",
+		},
+	}
+	cfg, errs := ParseOperatorConfig(cm)
+	tests := []Test{
+		{"Invalid keys", 3, len(errs)},
+		{"Default hostname", "apps.example.com", cfg.DefaultHostname},
+		{"Default pull policy", corev1.PullAlways, cfg.DefaultPullPolicy},
+		{"Default memory request", "512Mi", cfg.DefaultResources.Requests.Memory().String()},
+		{"Invalid issuer kind uses the default", "ClusterIssuer", cfg.CertManagerIssuer.Kind},
+		{"Invalid topology spread uses the default", true, cfg.DefaultTopologySpread},
+		{"Unknown key is not passed through", "", cfg.data["defaultPulPolicy"]},
+	}
+	if err := verifyTests(tests); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	// The defaults apply to the applications that do not set the fields
+	SetOperatorConfig(cfg)
+	defer SetOperatorConfig(DefaultOperatorConfig())
+	wl := createWebSphereLibertyApp(name, namespace, webspherelibertyv1.WebSphereLibertyApplicationSpec{})
+	wl.Initialize()
+	tests = []Test{
+		{"Pull policy", corev1.PullAlways, *wl.GetPullPolicy()},
+		{"Pull policy is not persisted", true, wl.Spec.PullPolicy == nil},
+		{"Resources", "100m", wl.GetResourceConstraints().Requests.Cpu().String()},
+		{"Readiness probe period", int32(5), wl.GetReadinessProbe().PeriodSeconds},
+		{"Readiness probe endpoint", "/health/ready", wl.GetReadinessProbe().HTTPGet.Path},
+		{"Liveness probe is not enabled", (*corev1.Probe)(nil), wl.GetLivenessProbe()},
+		{"Issuer", "my-issuer", GetCertManagerIssuerRef().Name},
+		{"Hostname defaults", name + "-" + namespace + ".apps.example.com", GetRouteHost(wl)},
+		{"Runtime component operator configuration", "apps.example.com", common.Config[common.OpConfigDefaultHostname]},
+	}
+	if err := verifyTests(tests); err != nil {
+		t.Fatalf("%v", err)
+	}
+}
+
+func TestLockOperatorConfig(t *testing.T) {
+	cfg, _ := ParseOperatorConfig(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: OperatorConfigMapName, Namespace: namespace},
+		Data:       map[string]string{common.OpConfigDefaultHostname: "apps.example.com"},
+	})
+	defer SetOperatorConfig(DefaultOperatorConfig())
+
+	// The configuration is not replaced while it is locked by a reconcile
+	unlock := LockOperatorConfig()
+	done := make(chan struct{})
+	go func() {
+		SetOperatorConfig(cfg)
+		close(done)
+	}()
+	select {
+	case <-done:
+		t.Fatalf("Operator configuration was replaced while it was locked")
+	case <-time.After(100 * time.Millisecond):
+	}
+	if common.Config[common.OpConfigDefaultHostname] != "" {
+		t.Fatalf("Runtime component operator configuration was replaced while it was locked")
+	}
+
+	unlock()
+	<-done
+	tests := []Test{
+		{"Hostname", "apps.example.com", GetOperatorConfig().DefaultHostname},
+		{"Runtime component operator configuration", "apps.example.com", common.Config[common.OpConfigDefaultHostname]},
+	}
+	if err := verifyTests(tests); err != nil {
+		t.Fatalf("%v", err)
 	}
-	return nil
 }